@@ -0,0 +1,146 @@
+package releases
+
+import (
+	"context"
+	"fmt"
+)
+
+// AdviceSeverity indicates how urgently an installed release should be upgraded.
+type AdviceSeverity string
+
+const (
+	// AdviceSeverityNone indicates that the installed release is the latest available.
+	AdviceSeverityNone AdviceSeverity = "none"
+
+	// AdviceSeverityLow indicates that a newer major or minor release is available, but the
+	// installed release is the latest patch in its line and remains supported.
+	AdviceSeverityLow AdviceSeverity = "low"
+
+	// AdviceSeverityMedium indicates that a newer patch release is available in the same line as
+	// the installed release.
+	AdviceSeverityMedium AdviceSeverity = "medium"
+
+	// AdviceSeverityHigh indicates that the installed release is no longer supported.
+	AdviceSeverityHigh AdviceSeverity = "high"
+
+	// AdviceSeverityCritical indicates that the installed release has been withdrawn.
+	AdviceSeverityCritical AdviceSeverity = "critical"
+)
+
+// Advice describes the upgrades available for an installed release of a product.
+type Advice struct {
+	// Installed is the metadata for the installed release.
+	Installed ReleaseInfo
+
+	// LatestPatch is the newest release with the same major and minor version as the installed
+	// release. It is nil if there is no such release which has not been withdrawn.
+	LatestPatch *ReleaseInfo
+
+	// LatestMinor is the newest release with the same major version as the installed release. It
+	// is nil if there is no such release which has not been withdrawn.
+	LatestMinor *ReleaseInfo
+
+	// LatestOverall is the newest release of the product. It is nil if every release has been
+	// withdrawn.
+	LatestOverall *ReleaseInfo
+
+	// Unsupported is set to true if the installed release is no longer supported.
+	Unsupported bool
+
+	// Withdrawn is set to true if the installed release has been withdrawn. The reason is given in
+	// Installed.Status.Message.
+	Withdrawn bool
+
+	// Severity indicates how urgently the installed release should be upgraded.
+	Severity AdviceSeverity
+}
+
+// Advise returns advice on upgrading an installed release of the nominated product and license
+// class. Releases considered as upgrades exclude prereleases and withdrawn releases, unless the
+// installed release is itself a prerelease, in which case newer prereleases are considered too.
+//
+// Severity is determined by the most pressing of the following: the installed release has been
+// withdrawn (critical), is unsupported (high), has a newer patch release (medium), or has a newer
+// minor or major release (low).
+func (c *Client) Advise(ctx context.Context, product string, installedVersion string, licenseClass *LicenseClass) (Advice, error) {
+	return Advise(ctx, c, product, installedVersion, licenseClass)
+}
+
+// Advise returns advice on upgrading an installed release of the nominated product and license
+// class, using the releases retrieved from api, as described for Client.Advise.
+func Advise(ctx context.Context, api ReleasesAPI, product string, installedVersion string, licenseClass *LicenseClass) (Advice, error) {
+	installed, err := parseVersion(installedVersion)
+	if err != nil {
+		return Advice{}, err
+	}
+
+	items, err := api.Releases(ctx, product, licenseClass)
+	if err != nil {
+		return Advice{}, err
+	}
+
+	var advice Advice
+	var latestPatch, latestMinor, latestOverall version
+	installedFound := false
+
+	for release, err := range items {
+		if err != nil {
+			return Advice{}, err
+		}
+
+		v, err := parseVersion(release.Version)
+		if err != nil {
+			continue
+		}
+
+		if !installedFound && (release.Version == installedVersion || v.compare(installed) == 0) {
+			advice.Installed = release
+			installedFound = true
+		}
+
+		if release.Status.State == ReleaseStateWithdrawn {
+			continue
+		}
+		if (release.IsPrerelease || v.prerelease != "") && installed.prerelease == "" {
+			continue
+		}
+
+		if advice.LatestOverall == nil || v.compare(latestOverall) > 0 {
+			advice.LatestOverall, latestOverall = &release, v
+		}
+		if v.major != installed.major {
+			continue
+		}
+		if advice.LatestMinor == nil || v.compare(latestMinor) > 0 {
+			advice.LatestMinor, latestMinor = &release, v
+		}
+		if v.minor != installed.minor {
+			continue
+		}
+		if advice.LatestPatch == nil || v.compare(latestPatch) > 0 {
+			advice.LatestPatch, latestPatch = &release, v
+		}
+	}
+
+	if !installedFound {
+		return Advice{}, fmt.Errorf("%w: %s %s does not exist", ErrInvalidVersion, product, installedVersion)
+	}
+
+	advice.Withdrawn = advice.Installed.Status.State == ReleaseStateWithdrawn
+	advice.Unsupported = advice.Installed.Status.State == ReleaseStateUnsupported
+
+	switch {
+	case advice.Withdrawn:
+		advice.Severity = AdviceSeverityCritical
+	case advice.Unsupported:
+		advice.Severity = AdviceSeverityHigh
+	case advice.LatestPatch != nil && latestPatch.compare(installed) > 0:
+		advice.Severity = AdviceSeverityMedium
+	case advice.LatestOverall != nil && latestOverall.compare(installed) > 0:
+		advice.Severity = AdviceSeverityLow
+	default:
+		advice.Severity = AdviceSeverityNone
+	}
+
+	return advice, nil
+}
@@ -0,0 +1,102 @@
+package releases_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+func TestClient_Advise(t *testing.T) {
+	supported := releases.ReleaseStatus{State: releases.ReleaseStateSupported}
+	unsupported := releases.ReleaseStatus{State: releases.ReleaseStateUnsupported}
+	withdrawn := releases.ReleaseStatus{State: releases.ReleaseStateWithdrawn, Message: "Data loss in raft storage"}
+
+	var fixtures []releases.ReleaseInfo
+	for i, spec := range []struct {
+		version string
+		status  releases.ReleaseStatus
+	}{
+		{"2.0.0-beta1", supported},
+		{"1.15.3", withdrawn},
+		{"1.15.2", supported},
+		{"1.14.9", supported},
+		{"1.15.1", supported},
+		{"1.15.0", supported},
+		{"1.14.8", withdrawn},
+		{"1.13.4", unsupported},
+		{"0.12.0", unsupported},
+	} {
+		fixtures = append(fixtures, releases.ReleaseInfo{
+			Name:             "vault",
+			Version:          spec.version,
+			IsPrerelease:     spec.version == "2.0.0-beta1",
+			Status:           spec.status,
+			TimestampCreated: time.Date(2024, time.March, 30-i, 0, 0, 0, 0, time.UTC),
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveTestReleasesPage(t, w, r, fixtures)
+	}))
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL))
+	requireNoError(t, err)
+
+	testCases := []struct {
+		installed   string
+		latestPatch string
+		latestMinor string
+		severity    releases.AdviceSeverity
+	}{
+		{installed: "1.15.2", latestPatch: "1.15.2", latestMinor: "1.15.2", severity: releases.AdviceSeverityNone},
+		{installed: "1.15.0", latestPatch: "1.15.2", latestMinor: "1.15.2", severity: releases.AdviceSeverityMedium},
+		{installed: "1.14.9", latestPatch: "1.14.9", latestMinor: "1.15.2", severity: releases.AdviceSeverityLow},
+		{installed: "1.13.4", latestPatch: "1.13.4", latestMinor: "1.15.2", severity: releases.AdviceSeverityHigh},
+		{installed: "1.14.8", latestPatch: "1.14.9", latestMinor: "1.15.2", severity: releases.AdviceSeverityCritical},
+		{installed: "1.15.3", latestPatch: "1.15.2", latestMinor: "1.15.2", severity: releases.AdviceSeverityCritical},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.installed, func(t *testing.T) {
+			advice, err := client.Advise(context.Background(), "vault", tc.installed, releases.LicenseClassOSS)
+			requireNoError(t, err)
+
+			requireEqual(t, tc.installed, advice.Installed.Version)
+			requireEqual(t, tc.latestPatch, advice.LatestPatch.Version)
+			requireEqual(t, tc.latestMinor, advice.LatestMinor.Version)
+			requireEqual(t, "1.15.2", advice.LatestOverall.Version)
+			requireEqual(t, tc.severity, advice.Severity)
+			requireEqual(t, tc.severity == releases.AdviceSeverityCritical, advice.Withdrawn)
+			requireEqual(t, tc.severity == releases.AdviceSeverityHigh, advice.Unsupported)
+		})
+	}
+
+	t.Run("Prerelease Installed", func(t *testing.T) {
+		advice, err := client.Advise(context.Background(), "vault", "2.0.0-beta1", releases.LicenseClassOSS)
+		requireNoError(t, err)
+
+		requireEqual(t, "2.0.0-beta1", advice.LatestOverall.Version)
+		requireEqual(t, releases.AdviceSeverityNone, advice.Severity)
+	})
+
+	t.Run("Unknown Version", func(t *testing.T) {
+		_, err := client.Advise(context.Background(), "vault", "1.16.0", releases.LicenseClassOSS)
+		if !errors.Is(err, releases.ErrInvalidVersion) {
+			t.Fatalf("expected ErrInvalidVersion, got: %v", err)
+		}
+	})
+
+	t.Run("Snapshot", func(t *testing.T) {
+		advice, err := releases.Advise(context.Background(), releases.NewSnapshot(fixtures...), "vault", "1.15.0", nil)
+		requireNoError(t, err)
+
+		requireEqual(t, "1.15.2", advice.LatestPatch.Version)
+		requireEqual(t, releases.AdviceSeverityMedium, advice.Severity)
+	})
+}
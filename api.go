@@ -1,7 +1,9 @@
 package releases
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
 	"strings"
 	"time"
 )
@@ -36,6 +38,15 @@ func licenseClassNames() string {
 	return fmt.Sprintf("%s or %s", strings.Join(elems[:len(elems)-2], ", "), elems[len(elems)-1])
 }
 
+func validateLicenseClass(licenseClass *LicenseClass) error {
+	switch licenseClass {
+	case nil, LicenseClassAny, LicenseClassOSS, LicenseClassEnterprise, LicenseClassHCP:
+		return nil
+	default:
+		return fmt.Errorf("%w: must be one of %s", ErrInvalidLicenseClass, licenseClassNames())
+	}
+}
+
 // ReleaseState represents whether a release of a product is within support, out of support, or has been withdrawn.
 type ReleaseState string
 
@@ -63,6 +74,22 @@ type BuildInfo struct {
 
 	// URL is a URL from which this build may be downloaded.
 	URL string `json:"url"`
+
+	// extra holds any members of the JSON representation of this build which are not otherwise
+	// recognized. It is returned by Extra.
+	extra *jsonExtra
+}
+
+// Extra returns any members of the JSON representation of this build which are not otherwise
+// recognized, such as those added to the API after this library was released, or nil if there are
+// none. They are preserved when the build is encoded as JSON.
+func (b BuildInfo) Extra() map[string]json.RawMessage {
+	return b.extra.members()
+}
+
+// SetExtra replaces the members returned by Extra with a copy of extra.
+func (b *BuildInfo) SetExtra(extra map[string]json.RawMessage) {
+	b.extra = newJSONExtra(maps.Clone(extra))
 }
 
 // ReleaseStatus provides information about the status of this release.
@@ -72,6 +99,26 @@ type ReleaseStatus struct {
 
 	// State indicates whether this release is supported, unsupported or withdrawn.
 	State ReleaseState `json:"state"`
+
+	// TimestampUpdated is the time at which State was last changed. It is zero if the API did not
+	// report it, in which case it is omitted when the status is encoded as JSON.
+	TimestampUpdated time.Time `json:"timestamp_updated"`
+
+	// extra holds any members of the JSON representation of this status which are not otherwise
+	// recognized. It is returned by Extra.
+	extra *jsonExtra
+}
+
+// Extra returns any members of the JSON representation of this status which are not otherwise
+// recognized, such as those added to the API after this library was released, or nil if there are
+// none. They are preserved when the status is encoded as JSON.
+func (s ReleaseStatus) Extra() map[string]json.RawMessage {
+	return s.extra.members()
+}
+
+// SetExtra replaces the members returned by Extra with a copy of extra.
+func (s *ReleaseStatus) SetExtra(extra map[string]json.RawMessage) {
+	s.extra = newJSONExtra(maps.Clone(extra))
 }
 
 // ReleaseInfo provides metadata about a specific release of a product.
@@ -136,4 +183,23 @@ type ReleaseInfo struct {
 
 	// Version is the version number of this release.
 	Version string `json:"version"`
+
+	// extra holds any members of the JSON representation of this release which are not otherwise
+	// recognized. It is returned by Extra.
+	extra *jsonExtra
+}
+
+// Extra returns any members of the JSON representation of this release which are not otherwise
+// recognized, such as those added to the API after this library was released, or nil if there are
+// none. They are preserved when the release is encoded as JSON.
+//
+// ReleaseInfo, BuildInfo and ReleaseStatus all expose these members using Extra and SetExtra
+// methods rather than a map field, so that BuildInfo and ReleaseStatus remain comparable using ==.
+func (r ReleaseInfo) Extra() map[string]json.RawMessage {
+	return r.extra.members()
+}
+
+// SetExtra replaces the members returned by Extra with a copy of extra.
+func (r *ReleaseInfo) SetExtra(extra map[string]json.RawMessage) {
+	r.extra = newJSONExtra(maps.Clone(extra))
 }
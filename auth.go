@@ -0,0 +1,226 @@
+package releases
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// TokenSource supplies bearer tokens with which to authenticate requests. Token is called for
+// each request, so implementations which obtain tokens from elsewhere should cache them until
+// they are due to expire.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc is an adapter which allows an ordinary function to be used as a TokenSource.
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token calls f(ctx).
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+type basicAuth struct {
+	username string
+	password string
+}
+
+// WithHeader adds an HTTP header to each authenticated request, for example to supply an API key
+// expected by a private mirror. See WithCredentialHosts for the requests to which credentials are
+// applied.
+func WithHeader(key string, value string) ClientOpt {
+	return func(opts *clientOpts) error {
+		if opts.authHeader == nil {
+			opts.authHeader = http.Header{}
+		}
+		opts.authHeader.Add(key, value)
+		return nil
+	}
+}
+
+// WithBearerToken authenticates requests using bearer tokens obtained from tokenSource. If a token
+// cannot be obtained, the request fails with an error wrapping ErrTokenSource. See
+// WithCredentialHosts for the requests to which credentials are applied.
+func WithBearerToken(tokenSource TokenSource) ClientOpt {
+	return func(opts *clientOpts) error {
+		if tokenSource == nil {
+			return fmt.Errorf("%w: token source may not be nil", ErrInvalidCredentials)
+		}
+		opts.tokenSource = tokenSource
+		return nil
+	}
+}
+
+// WithBasicAuth authenticates requests using HTTP basic authentication. See WithCredentialHosts
+// for the requests to which credentials are applied.
+func WithBasicAuth(username string, password string) ClientOpt {
+	return func(opts *clientOpts) error {
+		if username == "" {
+			return fmt.Errorf("%w: username may not be empty", ErrInvalidCredentials)
+		}
+		opts.basicAuth = &basicAuth{username: username, password: password}
+		return nil
+	}
+}
+
+// WithClientCertificate presents certificate when servers request a client certificate for mutual
+// TLS. It requires that the transport of the http.Client supplied using WithHTTPClient, if any, is
+// an *http.Transport. See WithCredentialHosts for the requests to which credentials are applied.
+func WithClientCertificate(certificate tls.Certificate) ClientOpt {
+	return func(opts *clientOpts) error {
+		if len(certificate.Certificate) == 0 {
+			return fmt.Errorf("%w: certificate chain may not be empty", ErrInvalidCredentials)
+		}
+		opts.clientCertificates = append(opts.clientCertificates, certificate)
+		return nil
+	}
+}
+
+// WithCredentialHosts adds hosts, in the form "host" or "host:port", to which the credentials
+// configured using WithHeader, WithBearerToken, WithBasicAuth and WithClientCertificate are sent.
+//
+// Credentials are always sent with requests to the hosts of the base URLs configured using
+// WithBaseURL or WithBaseURLs and to the hosts to which URLs are rewritten using WithURLRewrites,
+// including downloads of artifacts such as SHA256SUMS files served from them. Additional hosts
+// are needed only if artifacts are served from elsewhere.
+//
+// Credentials are sent only over HTTPS, unless a base URL or rewrite target for the host itself
+// uses plain HTTP. They are never sent with a request which follows a redirect to a different
+// host.
+func WithCredentialHosts(hosts ...string) ClientOpt {
+	return func(opts *clientOpts) error {
+		opts.credentialHosts = append(opts.credentialHosts, hosts...)
+		return nil
+	}
+}
+
+func (o *clientOpts) hasCredentials() bool {
+	return len(o.authHeader) > 0 || o.tokenSource != nil || o.basicAuth != nil ||
+		len(o.clientCertificates) > 0
+}
+
+// authenticatedHTTPClient returns a copy of the configured http.Client whose transport applies the
+// configured credentials, or the configured http.Client if there are none.
+func (o *clientOpts) authenticatedHTTPClient() (*http.Client, error) {
+	if !o.hasCredentials() {
+		return o.httpClient, nil
+	}
+
+	base := o.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	authenticated := base
+	if len(o.clientCertificates) > 0 {
+		transport, ok := base.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("%w: client certificates require an *http.Transport, got %T",
+				ErrInvalidCredentials, base)
+		}
+
+		transport = transport.Clone()
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.Certificates = append(transport.TLSClientConfig.Certificates,
+			o.clientCertificates...)
+		authenticated = transport
+	}
+
+	// Each trusted host maps to whether credentials may be sent to it over plain HTTP, which is
+	// permitted only if an endpoint configured for it uses HTTP.
+	hosts := make(map[string]bool)
+	trust := func(host string, allowHTTP bool) {
+		host = strings.ToLower(host)
+		hosts[host] = hosts[host] || allowHTTP
+	}
+	for _, endpoint := range append([]url.URL{o.baseURL}, o.fallbackURLs...) {
+		trust(endpoint.Host, endpoint.Scheme == "http")
+	}
+	for _, rule := range o.rewriteRules {
+		trust(rule.to.Host, rule.to.Scheme == "http")
+	}
+	for _, host := range o.credentialHosts {
+		trust(host, false)
+	}
+
+	httpClient := *o.httpClient
+	httpClient.Transport = &authTransport{
+		base:          base,
+		authenticated: authenticated,
+		hosts:         hosts,
+		header:        o.authHeader,
+		tokenSource:   o.tokenSource,
+		basicAuth:     o.basicAuth,
+	}
+	return &httpClient, nil
+}
+
+// authTransport applies credentials to requests made to trusted hosts over HTTPS, or over HTTP
+// where that is how the host is configured, other than those which follow a redirect from a
+// different host. Requests to which credentials are applied are made using the authenticated
+// transport, which may present a client certificate.
+type authTransport struct {
+	base          http.RoundTripper
+	authenticated http.RoundTripper
+	hosts         map[string]bool
+	header        http.Header
+	tokenSource   TokenSource
+	basicAuth     *basicAuth
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.trusted(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	for key, values := range t.header {
+		req.Header[key] = values
+	}
+	if t.basicAuth != nil {
+		req.SetBasicAuth(t.basicAuth.username, t.basicAuth.password)
+	}
+	if t.tokenSource != nil {
+		token, err := t.tokenSource.Token(req.Context())
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrTokenSource, err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return t.authenticated.RoundTrip(req)
+}
+
+// trusted returns true if credentials may be sent with req: its host must be trusted, it must use
+// HTTPS unless the host is configured with an HTTP endpoint, and if it follows a redirect, the
+// original request must have been made to the same host.
+func (t *authTransport) trusted(req *http.Request) bool {
+	host := strings.ToLower(req.URL.Host)
+	allowHTTP, ok := t.hosts[host]
+	if !ok {
+		return false
+	}
+	if req.URL.Scheme != "https" && !(req.URL.Scheme == "http" && allowHTTP) {
+		return false
+	}
+
+	for via := req.Response; via != nil && via.Request != nil; via = via.Request.Response {
+		if !strings.EqualFold(via.Request.URL.Host, host) {
+			return false
+		}
+	}
+	return true
+}
+
+// isCredentialsError returns true if err indicates that credentials could not be obtained, in
+// which case the request is not retried.
+func isCredentialsError(err error) bool {
+	return errors.Is(err, ErrTokenSource)
+}
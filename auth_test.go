@@ -0,0 +1,215 @@
+package releases_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+func TestCredentials(t *testing.T) {
+	var foreignHeader http.Header
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		foreignHeader = r.Header.Clone()
+		if strings.HasSuffix(r.URL.Path, "SHA256SUMS") {
+			_, _ = w.Write([]byte(testChecksums))
+			return
+		}
+		makeTestReleasesHandler(t).ServeHTTP(w, r)
+	}))
+	defer foreign.Close()
+
+	var apiHeader http.Header
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiHeader = r.Header.Clone()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "SHA256SUMS"):
+			_, _ = w.Write([]byte(testChecksums))
+		case r.URL.Path == "/v1/releases/waypoint/latest":
+			http.Redirect(w, r, foreign.URL+r.URL.Path, http.StatusFound)
+		default:
+			makeTestReleasesHandler(t).ServeHTTP(w, r)
+		}
+	}))
+	defer api.Close()
+
+	client, err := releases.New(
+		releases.WithBaseURL(api.URL),
+		releases.WithHeader("X-JFrog-Art-Api", "api-key"),
+		releases.WithBearerToken(releases.TokenSourceFunc(func(ctx context.Context) (string, error) {
+			return "token", nil
+		})))
+	requireNoError(t, err)
+
+	t.Run("API", func(t *testing.T) {
+		_, err := client.Release(context.Background(), "waypoint", "0.1.0")
+		requireNoError(t, err)
+		requireEqual(t, "Bearer token", apiHeader.Get("Authorization"))
+		requireEqual(t, "api-key", apiHeader.Get("X-JFrog-Art-Api"))
+	})
+
+	t.Run("Redirect To Different Host", func(t *testing.T) {
+		foreignHeader = nil
+		_, err := client.LatestRelease(context.Background(), "waypoint", nil)
+		requireNoError(t, err)
+		if foreignHeader == nil {
+			t.Fatal("expected request to follow redirect")
+		}
+		requireEqual(t, "", foreignHeader.Get("Authorization"))
+		requireEqual(t, "", foreignHeader.Get("X-JFrog-Art-Api"))
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		release := waypoint_0_11_4
+		release.URLSHASUMs = api.URL + "/waypoint/0.11.4/waypoint_0.11.4_SHA256SUMS"
+		_, err := client.Checksums(context.Background(), release)
+		requireNoError(t, err)
+		requireEqual(t, "Bearer token", apiHeader.Get("Authorization"))
+
+		release.URLSHASUMs = foreign.URL + "/waypoint/0.11.4/waypoint_0.11.4_SHA256SUMS"
+		_, err = client.Checksums(context.Background(), release)
+		requireNoError(t, err)
+		requireEqual(t, "", foreignHeader.Get("Authorization"))
+	})
+
+	t.Run("Credential Hosts", func(t *testing.T) {
+		var secureHeader http.Header
+		secure := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			secureHeader = r.Header.Clone()
+			_, _ = w.Write([]byte(testChecksums))
+		}))
+		defer secure.Close()
+
+		client, err := releases.New(
+			releases.WithBaseURL(api.URL),
+			releases.WithHTTPClient(secure.Client()),
+			releases.WithBasicAuth("user", "pass"),
+			releases.WithCredentialHosts(strings.TrimPrefix(secure.URL, "https://")))
+		requireNoError(t, err)
+
+		release := waypoint_0_11_4
+		release.URLSHASUMs = secure.URL + "/waypoint/0.11.4/waypoint_0.11.4_SHA256SUMS"
+		_, err = client.Checksums(context.Background(), release)
+		requireNoError(t, err)
+
+		username, password, ok := (&http.Request{Header: secureHeader}).BasicAuth()
+		requireEqual(t, true, ok)
+		requireEqual(t, "user", username)
+		requireEqual(t, "pass", password)
+	})
+
+	t.Run("Credential Hosts Over HTTP", func(t *testing.T) {
+		client, err := releases.New(
+			releases.WithBaseURL(api.URL),
+			releases.WithBasicAuth("user", "pass"),
+			releases.WithCredentialHosts(strings.TrimPrefix(foreign.URL, "http://")))
+		requireNoError(t, err)
+
+		release := waypoint_0_11_4
+		release.URLSHASUMs = foreign.URL + "/waypoint/0.11.4/waypoint_0.11.4_SHA256SUMS"
+		_, err = client.Checksums(context.Background(), release)
+		requireNoError(t, err)
+
+		if _, _, ok := (&http.Request{Header: foreignHeader}).BasicAuth(); ok {
+			t.Fatal("credentials must not be sent over HTTP to a host without an HTTP endpoint")
+		}
+	})
+}
+
+func TestWithBearerToken_Error(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	tokenErr := errors.New("token expired")
+	client, err := releases.New(
+		releases.WithBaseURL(server.URL),
+		releases.WithRetries(2),
+		releases.WithBearerToken(releases.TokenSourceFunc(func(ctx context.Context) (string, error) {
+			return "", tokenErr
+		})))
+	requireNoError(t, err)
+
+	_, err = client.Products(context.Background())
+	if !errors.Is(err, releases.ErrTokenSource) || !errors.Is(err, tokenErr) {
+		t.Fatalf("expected ErrTokenSource wrapping cause, got: %v", err)
+	}
+	requireEqual(t, int32(0), requests.Load())
+
+	if _, err := releases.New(releases.WithBearerToken(nil)); !errors.Is(err, releases.ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials, got: %v", err)
+	}
+}
+
+func TestWithClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) != 1 || r.TLS.PeerCertificates[0].Subject.CommonName != "releases-client" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		makeTestReleasesHandler(t).ServeHTTP(w, r)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	client, err := releases.New(
+		releases.WithBaseURL(server.URL),
+		releases.WithHTTPClient(server.Client()),
+		releases.WithClientCertificate(makeTestCertificate(t)))
+	requireNoError(t, err)
+
+	release, err := client.Release(context.Background(), "waypoint", "0.1.0")
+	requireNoError(t, err)
+	requireEqual(t, waypoint_0_1_0, release)
+
+	withoutCertificate, err := releases.New(
+		releases.WithBaseURL(server.URL),
+		releases.WithHTTPClient(server.Client()))
+	requireNoError(t, err)
+
+	if _, err := withoutCertificate.Release(context.Background(), "waypoint", "0.1.0"); !errors.Is(err, releases.ErrInvalidStatusCode) {
+		t.Fatalf("expected ErrInvalidStatusCode without client certificate, got: %v", err)
+	}
+
+	_, err = releases.New(
+		releases.WithHTTPClient(&http.Client{Transport: roundTripFunc(http.DefaultTransport.RoundTrip)}),
+		releases.WithClientCertificate(makeTestCertificate(t)))
+	if !errors.Is(err, releases.ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials for custom transport, got: %v", err)
+	}
+}
+
+func makeTestCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	requireNoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "releases-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	requireNoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
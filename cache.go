@@ -0,0 +1,233 @@
+package releases
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+	"time"
+)
+
+// CachingAPI is a ReleasesAPI which caches the results of successful calls to another ReleasesAPI,
+// such as a Client, for a fixed period. Errors are not cached. The results of Releases and
+// ReleasesPaged are cached only once they have been iterated to completion.
+//
+// Calls made with WithCallCacheBypass are passed to the underlying ReleasesAPI, and their results
+// replace any which are cached. Calls made with WithCallBaseURL are cached separately for each base
+// URL. Other options are passed to the underlying ReleasesAPI on a cache miss, and otherwise have no
+// effect; in particular, the function supplied using WithCallServedBy is not called when a result
+// is served from the cache.
+type CachingAPI struct {
+	api ReleasesAPI
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+var _ ReleasesAPI = (*CachingAPI)(nil)
+
+// NewCachingAPI returns a CachingAPI which caches the results of calls to api for ttl, which must
+// be positive.
+func NewCachingAPI(api ReleasesAPI, ttl time.Duration) (*CachingAPI, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("%w: cache duration must be positive, got %s", ErrInvalidInterval, ttl)
+	}
+
+	return &CachingAPI{
+		api:     api,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cacheEntry),
+	}, nil
+}
+
+// Invalidate removes all cached results.
+func (c *CachingAPI) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+}
+
+// Products returns the names of all products tracked by the API.
+func (c *CachingAPI) Products(ctx context.Context, opts ...CallOpt) ([]string, error) {
+	key, cached, err := c.lookup(opts, "products")
+	if err != nil {
+		return nil, err
+	}
+	if products, ok := cached.([]string); ok {
+		return slices.Clone(products), nil
+	}
+
+	products, err := c.api.Products(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.store(key, slices.Clone(products))
+	return products, nil
+}
+
+// Release returns all metadata for a specific version of a product.
+func (c *CachingAPI) Release(ctx context.Context, product string, version string, opts ...CallOpt) (ReleaseInfo, error) {
+	key, cached, err := c.lookup(opts, "release", product, version)
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+	if release, ok := cached.(ReleaseInfo); ok {
+		return cloneRelease(release), nil
+	}
+
+	release, err := c.api.Release(ctx, product, version, opts...)
+	if err != nil {
+		return release, err
+	}
+	c.store(key, cloneRelease(release))
+	return release, nil
+}
+
+// LatestRelease returns all metadata for the latest release of a product with the given license
+// class, or of any license class if licenseClass is nil.
+func (c *CachingAPI) LatestRelease(ctx context.Context, product string, licenseClass *LicenseClass, opts ...CallOpt) (ReleaseInfo, error) {
+	key, cached, err := c.lookup(opts, "latest", product, licenseClassKey(licenseClass))
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+	if release, ok := cached.(ReleaseInfo); ok {
+		return cloneRelease(release), nil
+	}
+
+	release, err := c.api.LatestRelease(ctx, product, licenseClass, opts...)
+	if err != nil {
+		return release, err
+	}
+	c.store(key, cloneRelease(release))
+	return release, nil
+}
+
+// Releases returns an iterator over the releases of a product with the given license class,
+// newest first.
+func (c *CachingAPI) Releases(ctx context.Context, product string, licenseClass *LicenseClass, opts ...CallOpt) (iter.Seq2[ReleaseInfo, error], error) {
+	key, cached, err := c.lookup(opts, "releases", product, licenseClassKey(licenseClass))
+	if err != nil {
+		return nil, err
+	}
+	if items, ok := cached.([]ReleaseInfo); ok {
+		return func(yield func(ReleaseInfo, error) bool) {
+			for _, item := range items {
+				if !yield(cloneRelease(item), nil) {
+					return
+				}
+			}
+		}, nil
+	}
+
+	items, err := c.api.Releases(ctx, product, licenseClass, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(ReleaseInfo, error) bool) {
+		var collected []ReleaseInfo
+		for item, err := range items {
+			if err != nil {
+				_ = yield(ReleaseInfo{}, err)
+				return
+			}
+			collected = append(collected, cloneRelease(item))
+			if !yield(item, nil) {
+				return
+			}
+		}
+		c.store(key, collected)
+	}, nil
+}
+
+// ReleasesPaged returns an iterator over pages of the releases of a product with the given license
+// class, newest first.
+func (c *CachingAPI) ReleasesPaged(ctx context.Context, product string, licenseClass *LicenseClass, opts ...CallOpt) (iter.Seq2[[]ReleaseInfo, error], error) {
+	key, cached, err := c.lookup(opts, "releases-paged", product, licenseClassKey(licenseClass))
+	if err != nil {
+		return nil, err
+	}
+	if pages, ok := cached.([][]ReleaseInfo); ok {
+		return func(yield func([]ReleaseInfo, error) bool) {
+			for _, page := range pages {
+				if !yield(cloneReleases(page), nil) {
+					return
+				}
+			}
+		}, nil
+	}
+
+	pages, err := c.api.ReleasesPaged(ctx, product, licenseClass, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func([]ReleaseInfo, error) bool) {
+		var collected [][]ReleaseInfo
+		for page, err := range pages {
+			if err != nil {
+				_ = yield(nil, err)
+				return
+			}
+			collected = append(collected, cloneReleases(page))
+			if !yield(page, nil) {
+				return
+			}
+		}
+		c.store(key, collected)
+	}, nil
+}
+
+// lookup returns the cache key for a call with the given options and arguments, along with the
+// cached result, which is nil if there is none, it has expired, or the call bypasses the cache.
+func (c *CachingAPI) lookup(opts []CallOpt, method string, args ...string) (string, any, error) {
+	effectiveOpts, err := applyCallOpts(opts)
+	if err != nil {
+		return "", nil, err
+	}
+	key := fmt.Sprintf("%s%q@%s", method, args, effectiveOpts.cacheKey())
+
+	if effectiveOpts.bypassCache {
+		return key, nil, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return key, nil, nil
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return key, nil, nil
+	}
+	return key, entry.value, nil
+}
+
+func (c *CachingAPI) store(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = cacheEntry{
+		value:   value,
+		expires: c.now().Add(c.ttl),
+	}
+}
+
+func licenseClassKey(licenseClass *LicenseClass) string {
+	if licenseClass == nil {
+		return "*"
+	}
+	return string(*licenseClass)
+}
@@ -0,0 +1,114 @@
+package releases_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+// countingAPI counts the calls made to a ReleasesAPI.
+type countingAPI struct {
+	releases.ReleasesAPI
+	calls int
+}
+
+func (c *countingAPI) Products(ctx context.Context, opts ...releases.CallOpt) ([]string, error) {
+	c.calls++
+	return c.ReleasesAPI.Products(ctx, opts...)
+}
+
+func (c *countingAPI) Release(ctx context.Context, product string, version string, opts ...releases.CallOpt) (releases.ReleaseInfo, error) {
+	c.calls++
+	return c.ReleasesAPI.Release(ctx, product, version, opts...)
+}
+
+func (c *countingAPI) Releases(ctx context.Context, product string, licenseClass *releases.LicenseClass, opts ...releases.CallOpt) (iter.Seq2[releases.ReleaseInfo, error], error) {
+	c.calls++
+	return c.ReleasesAPI.Releases(ctx, product, licenseClass, opts...)
+}
+
+func TestNewCachingAPI_InvalidTTL(t *testing.T) {
+	_, err := releases.NewCachingAPI(releases.NewSnapshot(), 0)
+	if !errors.Is(err, releases.ErrInvalidInterval) {
+		t.Fatalf("expected ErrInvalidInterval, got: %v", err)
+	}
+}
+
+func TestCachingAPI(t *testing.T) {
+	backend := &countingAPI{ReleasesAPI: releases.NewSnapshot(waypointReleases...)}
+	cache, err := releases.NewCachingAPI(backend, time.Hour)
+	requireNoError(t, err)
+	ctx := context.Background()
+
+	for range 2 {
+		release, err := cache.Release(ctx, "waypoint", "0.11.4")
+		requireNoError(t, err)
+		requireEqual(t, waypoint_0_11_4, release)
+
+		// Modifying a cached result does not affect the cache.
+		release.Builds[0].URL = "https://example.com/modified"
+	}
+	requireEqual(t, 1, backend.calls)
+
+	_, err = cache.Release(ctx, "waypoint", "0.11.4", releases.WithCallCacheBypass())
+	requireNoError(t, err)
+	requireEqual(t, 2, backend.calls)
+
+	_, err = cache.Release(ctx, "waypoint", "0.11.4", releases.WithCallBaseURL("https://mirror.example.com"))
+	requireNoError(t, err)
+	requireEqual(t, 3, backend.calls)
+
+	_, err = cache.Release(ctx, "waypoint", "0.0.1")
+	if !errors.Is(err, releases.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+	_, err = cache.Release(ctx, "waypoint", "0.0.1")
+	if !errors.Is(err, releases.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+	requireEqual(t, 5, backend.calls)
+
+	cache.Invalidate()
+	_, err = cache.Release(ctx, "waypoint", "0.11.4")
+	requireNoError(t, err)
+	requireEqual(t, 6, backend.calls)
+}
+
+func TestCachingAPI_Releases(t *testing.T) {
+	backend := &countingAPI{ReleasesAPI: releases.NewSnapshot(waypointReleases...)}
+	cache, err := releases.NewCachingAPI(backend, time.Hour)
+	requireNoError(t, err)
+	ctx := context.Background()
+
+	// An incomplete iteration is not cached.
+	items, err := cache.Releases(ctx, "waypoint", releases.LicenseClassOSS)
+	requireNoError(t, err)
+	for range items {
+		break
+	}
+
+	for range 2 {
+		items, err := cache.Releases(ctx, "waypoint", releases.LicenseClassOSS)
+		requireNoError(t, err)
+		requireEqual(t, versionsOf(waypointReleases), versionsOf(collectResults(t, items)))
+	}
+	requireEqual(t, 2, backend.calls)
+}
+
+func TestCachingAPI_Expiry(t *testing.T) {
+	backend := &countingAPI{ReleasesAPI: releases.NewSnapshot(waypointReleases...)}
+	cache, err := releases.NewCachingAPI(backend, 10*time.Millisecond)
+	requireNoError(t, err)
+
+	_, err = cache.Products(context.Background())
+	requireNoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+	_, err = cache.Products(context.Background())
+	requireNoError(t, err)
+
+	requireEqual(t, 2, backend.calls)
+}
@@ -0,0 +1,343 @@
+package releases
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ChangeCategory classifies the entries in a section of a product changelog.
+type ChangeCategory string
+
+const (
+	// ChangeCategoryBreakingChanges classifies entries describing backwards-incompatible changes.
+	ChangeCategoryBreakingChanges ChangeCategory = "breaking_changes"
+
+	// ChangeCategorySecurity classifies entries describing security fixes.
+	ChangeCategorySecurity ChangeCategory = "security"
+
+	// ChangeCategoryFeatures classifies entries describing new features.
+	ChangeCategoryFeatures ChangeCategory = "features"
+
+	// ChangeCategoryImprovements classifies entries describing improvements to existing features.
+	ChangeCategoryImprovements ChangeCategory = "improvements"
+
+	// ChangeCategoryDeprecations classifies entries describing deprecated functionality.
+	ChangeCategoryDeprecations ChangeCategory = "deprecations"
+
+	// ChangeCategoryBugFixes classifies entries describing bug fixes.
+	ChangeCategoryBugFixes ChangeCategory = "bug_fixes"
+
+	// ChangeCategoryNotes classifies entries such as upgrade notes and known issues.
+	ChangeCategoryNotes ChangeCategory = "notes"
+
+	// ChangeCategoryOther classifies entries which appear outside a recognized section heading.
+	ChangeCategoryOther ChangeCategory = "other"
+)
+
+var changeCategoryTitles = map[string]ChangeCategory{
+	"BREAKING CHANGES":            ChangeCategoryBreakingChanges,
+	"BREAKING CHANGE":             ChangeCategoryBreakingChanges,
+	"BACKWARDS INCOMPATIBILITIES": ChangeCategoryBreakingChanges,
+	"SECURITY":                    ChangeCategorySecurity,
+	"SECURITY FIXES":              ChangeCategorySecurity,
+	"FEATURES":                    ChangeCategoryFeatures,
+	"FEATURE":                     ChangeCategoryFeatures,
+	"NEW FEATURES":                ChangeCategoryFeatures,
+	"MAJOR FEATURES":              ChangeCategoryFeatures,
+	"IMPROVEMENTS":                ChangeCategoryImprovements,
+	"ENHANCEMENTS":                ChangeCategoryImprovements,
+	"CHANGES":                     ChangeCategoryImprovements,
+	"DEPRECATIONS":                ChangeCategoryDeprecations,
+	"BUG FIXES":                   ChangeCategoryBugFixes,
+	"BUG FIX":                     ChangeCategoryBugFixes,
+	"BUGS":                        ChangeCategoryBugFixes,
+	"NOTES":                       ChangeCategoryNotes,
+	"UPGRADE NOTES":               ChangeCategoryNotes,
+	"KNOWN ISSUES":                ChangeCategoryNotes,
+}
+
+// ChangelogSection is a group of changelog entries under a single heading, such as "BUG FIXES:".
+type ChangelogSection struct {
+	// Category classifies the entries in this section.
+	Category ChangeCategory
+
+	// Title is the heading of the section as it appears in the changelog, without any Markdown
+	// heading markers or trailing colon.
+	Title string
+
+	// Entries holds the text of each entry in the section, without the leading list marker.
+	// Continuation lines are retained, separated by newlines.
+	Entries []string
+}
+
+// ChangelogVersion is the part of a product changelog which describes a single version.
+type ChangelogVersion struct {
+	// Version is the version number as it appears in the heading, without any leading "v".
+	Version string
+
+	// Date is the release date as it appears in the changelog, for example "September 7, 2023"
+	// or "Unreleased". It is empty if no date was given.
+	Date string
+
+	// Sections holds each section of the changelog for this version, in order of appearance.
+	Sections []ChangelogSection
+}
+
+// Entries returns the entries in every section of the given category, in order of appearance.
+func (v ChangelogVersion) Entries(category ChangeCategory) []string {
+	var entries []string
+	for _, section := range v.Sections {
+		if section.Category == category {
+			entries = append(entries, section.Entries...)
+		}
+	}
+	return entries
+}
+
+// SecurityEntries returns the entries in security sections, together with entries in other
+// sections which are labelled as security changes, such as "security: Fix ...".
+func (v ChangelogVersion) SecurityEntries() []string {
+	var entries []string
+	for _, section := range v.Sections {
+		for _, entry := range section.Entries {
+			if section.Category == ChangeCategorySecurity || isSecurityLabelled(entry) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
+}
+
+func isSecurityLabelled(entry string) bool {
+	lower := strings.ToLower(entry)
+	return strings.HasPrefix(lower, "security:") ||
+		strings.HasPrefix(lower, "**security**") ||
+		strings.HasPrefix(lower, "[security]") ||
+		strings.Contains(lower, "cve-")
+}
+
+var (
+	changelogVersionHeading  = regexp.MustCompile(`^#{1,2}\s+\[?v?(\d+\.\d+(?:\.\d+)?[^\s\]()]*)\]?\s*(?:\((.*)\)|[-–]\s*(.*))?\s*$`)
+	changelogSectionHeading  = regexp.MustCompile(`^(?:#{3,4}\s+)?\**([A-Za-z][A-Za-z ]*?)\**:?\s*$`)
+	changelogListItem        = regexp.MustCompile(`^\s{0,1}[*-]\s+(.*)$`)
+	changelogUppercaseColons = regexp.MustCompile(`^[A-Z][A-Z ]+:\s*$`)
+)
+
+// ParseChangelog parses a Markdown product changelog, as referenced by ReleaseInfo.URLChangelog,
+// into a ChangelogVersion for each version heading, in order of appearance.
+//
+// The formats used by HashiCorp products vary. Version headings of the forms "## 1.5.7 (September
+// 7, 2023)", "## 1.15.0" followed by "### September 27, 2023", and "## [v1.0.0] - 2025-02-25" are
+// recognized. Sections may be introduced by upper case lines such as "BUG FIXES:", or by headings
+// such as "### Bug Fixes".
+func ParseChangelog(r io.Reader) ([]ChangelogVersion, error) {
+	var versions []ChangelogVersion
+	var current *ChangelogVersion
+	var section *ChangelogSection
+	expectDate := false
+
+	appendEntry := func(text string) {
+		if section == nil {
+			current.Sections = append(current.Sections, ChangelogSection{Category: ChangeCategoryOther})
+			section = &current.Sections[len(current.Sections)-1]
+		}
+		section.Entries = append(section.Entries, text)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		if match := changelogVersionHeading.FindStringSubmatch(line); match != nil {
+			versions = append(versions, ChangelogVersion{
+				Version: match[1],
+				Date:    strings.TrimSpace(match[2] + match[3]),
+			})
+			current = &versions[len(versions)-1]
+			section = nil
+			expectDate = current.Date == ""
+			continue
+		}
+
+		if strings.HasPrefix(line, "# ") || strings.HasPrefix(line, "## ") {
+			// A heading at the same level as a version heading which does not name a version, such
+			// as "## [Unreleased]", ends the preceding version.
+			current = nil
+			continue
+		}
+
+		if current == nil || strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if title, category, ok := parseSectionHeading(line); ok {
+			current.Sections = append(current.Sections, ChangelogSection{Category: category, Title: title})
+			section = &current.Sections[len(current.Sections)-1]
+			expectDate = false
+			continue
+		}
+
+		if expectDate && strings.HasPrefix(line, "###") {
+			current.Date = strings.TrimSpace(strings.TrimLeft(line, "#"))
+			expectDate = false
+			continue
+		}
+		expectDate = false
+
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if match := changelogListItem.FindStringSubmatch(line); match != nil {
+			appendEntry(match[1])
+			continue
+		}
+
+		if section != nil && len(section.Entries) > 0 && (line[0] == ' ' || line[0] == '\t') {
+			last := &section.Entries[len(section.Entries)-1]
+			*last += "\n" + strings.TrimSpace(line)
+			continue
+		}
+
+		appendEntry(strings.TrimSpace(line))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func parseSectionHeading(line string) (string, ChangeCategory, bool) {
+	isHeading := strings.HasPrefix(line, "###")
+	isUppercase := changelogUppercaseColons.MatchString(line)
+	isBold := strings.HasPrefix(line, "**") && strings.HasSuffix(strings.TrimSuffix(line, ":"), "**")
+	if !isHeading && !isUppercase && !isBold {
+		return "", "", false
+	}
+
+	match := changelogSectionHeading.FindStringSubmatch(line)
+	if match == nil {
+		return "", "", false
+	}
+
+	title := strings.TrimSpace(match[1])
+	if category, ok := changeCategoryTitles[strings.ToUpper(title)]; ok {
+		return title, category, true
+	}
+	if isUppercase {
+		return title, ChangeCategoryOther, true
+	}
+	return "", "", false
+}
+
+// Changelog retrieves the changelog referenced by release.URLChangelog, and returns the part of
+// it which describes release.Version. Links to files on github.com are retrieved from
+// raw.githubusercontent.com, in order to obtain Markdown rather than HTML.
+//
+// If the release has no changelog, ErrNoChangelog is returned. If the changelog does not describe
+// the version, ErrChangelogVersionNotFound is returned. If the release has been withdrawn, a
+// *ReleaseWithdrawnError is returned, unless the Client was constructed using WithAllowWithdrawn.
+func (c *Client) Changelog(ctx context.Context, release ReleaseInfo) (ChangelogVersion, error) {
+	if err := c.checkWithdrawn(release); err != nil {
+		return ChangelogVersion{}, err
+	}
+
+	versions, err := c.fetchChangelog(ctx, release)
+	if err != nil {
+		return ChangelogVersion{}, err
+	}
+
+	return findChangelogVersion(versions, release)
+}
+
+func (c *Client) fetchChangelog(ctx context.Context, release ReleaseInfo) ([]ChangelogVersion, error) {
+	return fetchChangelog(ctx, c.opts.httpClient, c.opts.userAgent, release)
+}
+
+// fetchChangelog retrieves and parses the changelog referenced by release.URLChangelog using
+// httpClient, sending userAgent if it is not nil.
+func fetchChangelog(ctx context.Context, httpClient *http.Client, userAgent *string, release ReleaseInfo) ([]ChangelogVersion, error) {
+	if release.URLChangelog == "" {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoChangelog, release.Name, release.Version)
+	}
+
+	changelogURL, err := rawChangelogURL(release.URLChangelog)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, changelogURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConstructingRequest, err)
+	}
+	req.Header.Set("Accept", "text/markdown, text/plain;q=0.9, */*;q=0.1")
+	if userAgent != nil {
+		req.Header.Set("User-Agent", *userAgent)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidStatusCode, resp.StatusCode)
+	}
+
+	return ParseChangelog(resp.Body)
+}
+
+func findChangelogVersion(versions []ChangelogVersion, release ReleaseInfo) (ChangelogVersion, error) {
+	want := strings.TrimPrefix(release.Version, "v")
+	for _, v := range versions {
+		if v.Version == want {
+			return v, nil
+		}
+	}
+
+	// Enterprise releases carry build metadata which changelogs typically omit.
+	if wantParsed, err := parseVersion(want); err == nil {
+		for _, v := range versions {
+			if parsed, err := parseVersion(v.Version); err == nil && parsed.compare(wantParsed) == 0 {
+				return v, nil
+			}
+		}
+	}
+
+	return ChangelogVersion{}, fmt.Errorf("%w: %s %s", ErrChangelogVersionNotFound, release.Name, release.Version)
+}
+
+// rawChangelogURL rewrites links to files on github.com to the corresponding URL on
+// raw.githubusercontent.com. Other URLs are returned unchanged.
+func rawChangelogURL(changelogURL string) (string, error) {
+	parsed, err := url.Parse(changelogURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrConstructingRequest, err)
+	}
+
+	if parsed.Host != "github.com" {
+		return changelogURL, nil
+	}
+
+	// https://github.com/{owner}/{repo}/blob/{ref...}/{path}
+	parts := strings.SplitN(strings.TrimPrefix(parsed.Path, "/"), "/", 4)
+	if len(parts) < 4 || parts[2] != "blob" {
+		return changelogURL, nil
+	}
+
+	parsed.Host = "raw.githubusercontent.com"
+	parsed.Path = "/" + parts[0] + "/" + parts[1] + "/" + parts[3]
+	parsed.RawPath = ""
+	return parsed.String(), nil
+}
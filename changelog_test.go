@@ -0,0 +1,165 @@
+package releases_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+const testTerraformChangelog = `## 1.6.0 (Unreleased)
+
+UPGRADE NOTES:
+
+* Some upgrade note.
+
+## 1.5.7 (September 7, 2023)
+
+BUG FIXES:
+* ` + "`terraform init`" + `: Fix crash when using invalid configuration in backend blocks ([#33628](https://github.com/hashicorp/terraform/issues/33628))
+* ` + "`terraform graph`" + `: Ensure ` + "`terraform graph`" + ` produces the correct graph
+  when the configuration contains a module. ([#33746](https://github.com/hashicorp/terraform/issues/33746))
+
+## 1.5.6 (August 23, 2023)
+
+ENHANCEMENTS:
+* security: Updated dependency golang.org/x/net to fix CVE-2023-3978.
+
+## Previous Releases
+
+For information on prior major and minor releases, see their changelogs.
+`
+
+const testVaultChangelog = `## 1.15.0
+### September 27, 2023
+
+SECURITY:
+
+* secrets/transit: fix a regression that was honoring nonces provided in non-convergent modes during encryption. [[GH-22852](https://github.com/hashicorp/vault/pull/22852)]
+
+FEATURES:
+
+* **Certificate Issuance External Policy Service (CIEPS) (enterprise)**: Allow highly-customizable operator control.
+
+## 1.14.4
+### September 27, 2023
+
+IMPROVEMENTS:
+
+* core: Bump Go version to 1.20.8.
+`
+
+const testKeepAChangelog = `# Changelog
+
+## [Unreleased]
+
+## [v1.0.0] - 2025-02-25
+
+### Features
+
+- A ` + "`Client`" + ` may be constructed with a custom URL.
+
+### Bug Fixes
+
+- Nothing yet.
+`
+
+func TestParseChangelog(t *testing.T) {
+	t.Run("Terraform", func(t *testing.T) {
+		versions, err := releases.ParseChangelog(strings.NewReader(testTerraformChangelog))
+		requireNoError(t, err)
+
+		requireEqual(t, 3, len(versions))
+		requireEqual(t, "Unreleased", versions[0].Date)
+
+		v157 := versions[1]
+		requireEqual(t, "1.5.7", v157.Version)
+		requireEqual(t, "September 7, 2023", v157.Date)
+		requireEqual(t, 1, len(v157.Sections))
+		requireEqual(t, "BUG FIXES", v157.Sections[0].Title)
+
+		bugFixes := v157.Entries(releases.ChangeCategoryBugFixes)
+		requireEqual(t, 2, len(bugFixes))
+		requireEqual(t, true, strings.HasPrefix(bugFixes[0], "`terraform init`: Fix crash"))
+		requireEqual(t, true, strings.HasSuffix(bugFixes[1], "\nwhen the configuration contains a module. ([#33746](https://github.com/hashicorp/terraform/issues/33746))"))
+
+		v156 := versions[2]
+		requireEqual(t, 1, len(v156.Entries(releases.ChangeCategoryImprovements)))
+		requireEqual(t, 1, len(v156.SecurityEntries()))
+		requireEqual(t, 1, len(v156.Sections))
+	})
+
+	t.Run("Vault", func(t *testing.T) {
+		versions, err := releases.ParseChangelog(strings.NewReader(testVaultChangelog))
+		requireNoError(t, err)
+
+		requireEqual(t, 2, len(versions))
+		requireEqual(t, "1.15.0", versions[0].Version)
+		requireEqual(t, "September 27, 2023", versions[0].Date)
+		requireEqual(t, 1, len(versions[0].Entries(releases.ChangeCategorySecurity)))
+		requireEqual(t, 1, len(versions[0].SecurityEntries()))
+		requireEqual(t, 1, len(versions[0].Entries(releases.ChangeCategoryFeatures)))
+		requireEqual(t, []string{"core: Bump Go version to 1.20.8."}, versions[1].Entries(releases.ChangeCategoryImprovements))
+	})
+
+	t.Run("Keep a Changelog", func(t *testing.T) {
+		versions, err := releases.ParseChangelog(strings.NewReader(testKeepAChangelog))
+		requireNoError(t, err)
+
+		requireEqual(t, 1, len(versions))
+		requireEqual(t, "1.0.0", versions[0].Version)
+		requireEqual(t, "2025-02-25", versions[0].Date)
+		requireEqual(t, []string{"Nothing yet."}, versions[0].Entries(releases.ChangeCategoryBugFixes))
+	})
+}
+
+func TestClient_Changelog(t *testing.T) {
+	var requested []string
+	client, err := releases.New(releases.WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requested = append(requested, req.URL.String())
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(testTerraformChangelog)),
+				Request:    req,
+			}, nil
+		}),
+	}))
+	requireNoError(t, err)
+
+	release := releases.ReleaseInfo{
+		Name:         "terraform",
+		Version:      "1.5.7",
+		URLChangelog: "https://github.com/hashicorp/terraform/blob/v1.5/CHANGELOG.md",
+	}
+
+	t.Run("Found", func(t *testing.T) {
+		section, err := client.Changelog(context.Background(), release)
+		requireNoError(t, err)
+
+		requireEqual(t, "1.5.7", section.Version)
+		requireEqual(t, 2, len(section.Entries(releases.ChangeCategoryBugFixes)))
+		requireEqual(t, "https://raw.githubusercontent.com/hashicorp/terraform/v1.5/CHANGELOG.md", requested[0])
+	})
+
+	t.Run("Version Not Found", func(t *testing.T) {
+		missing := release
+		missing.Version = "1.4.0"
+
+		_, err := client.Changelog(context.Background(), missing)
+		if !errors.Is(err, releases.ErrChangelogVersionNotFound) {
+			t.Fatalf("expected ErrChangelogVersionNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("No Changelog", func(t *testing.T) {
+		_, err := client.Changelog(context.Background(), releases.ReleaseInfo{Name: "terraform", Version: "0.1.0"})
+		if !errors.Is(err, releases.ErrNoChangelog) {
+			t.Fatalf("expected ErrNoChangelog, got: %v", err)
+		}
+	})
+}
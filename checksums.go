@@ -0,0 +1,265 @@
+package releases
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+)
+
+const maxChecksumsBytes = 1024 * 1024
+
+// Checksum is the SHA-256 digest of a file.
+type Checksum [sha256.Size]byte
+
+// ParseChecksum parses a checksum in any of the forms produced by Hex, ZH or SRI.
+func ParseChecksum(s string) (Checksum, error) {
+	var sum Checksum
+	var decoded []byte
+	var err error
+
+	switch {
+	case strings.HasPrefix(s, "zh:"):
+		decoded, err = hex.DecodeString(strings.TrimPrefix(s, "zh:"))
+	case strings.HasPrefix(s, "sha256-"):
+		decoded, err = base64.StdEncoding.DecodeString(strings.TrimPrefix(s, "sha256-"))
+	default:
+		decoded, err = hex.DecodeString(s)
+	}
+	if err != nil || len(decoded) != len(sum) {
+		return Checksum{}, fmt.Errorf("%w: %q is not a SHA-256 checksum", ErrInvalidChecksums, s)
+	}
+
+	copy(sum[:], decoded)
+	return sum, nil
+}
+
+// Hex returns the checksum as lowercase hexadecimal, as used in SHA256SUMS files.
+func (c Checksum) Hex() string {
+	return hex.EncodeToString(c[:])
+}
+
+// ZH returns the checksum in the "zh:" form which Terraform records in dependency lock files for
+// provider packages verified against SHA256SUMS.
+func (c Checksum) ZH() string {
+	return "zh:" + c.Hex()
+}
+
+// SRI returns the checksum in the form used for Subresource Integrity, for example in package
+// manager manifests.
+func (c Checksum) SRI() string {
+	return "sha256-" + base64.StdEncoding.EncodeToString(c[:])
+}
+
+func (c Checksum) String() string {
+	return c.Hex()
+}
+
+// Checksums holds the contents of a SHA256SUMS file, which lists the checksum of each build of a
+// release. It is obtained using Client.Checksums or ParseChecksums.
+type Checksums struct {
+	filenames []string
+	sums      map[string]Checksum
+}
+
+// ParseChecksums parses a SHA256SUMS file, in the format produced by sha256sum. Each line holds
+// the hexadecimal checksum of a file followed by its name, separated by two spaces, or by a space
+// and an asterisk. ErrInvalidChecksums is returned if a line is malformed, or if a file is listed
+// twice with different checksums.
+func ParseChecksums(r io.Reader) (Checksums, error) {
+	result := Checksums{
+		sums: map[string]Checksum{},
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		sumText, filename, ok := strings.Cut(text, " ")
+		filename, isBinary := strings.CutPrefix(filename, "*")
+		if !isBinary {
+			filename, ok = strings.CutPrefix(filename, " ")
+		}
+		if !ok || filename == "" {
+			return Checksums{}, fmt.Errorf("%w: line %d is malformed", ErrInvalidChecksums, line)
+		}
+
+		sum, err := hex.DecodeString(sumText)
+		if err != nil || len(sum) != sha256.Size {
+			return Checksums{}, fmt.Errorf("%w: line %d has an invalid checksum", ErrInvalidChecksums, line)
+		}
+
+		if existing, ok := result.sums[filename]; ok {
+			if Checksum(sum) != existing {
+				return Checksums{}, fmt.Errorf("%w: %s is listed with different checksums", ErrInvalidChecksums, filename)
+			}
+			continue
+		}
+		result.filenames = append(result.filenames, filename)
+		result.sums[filename] = Checksum(sum)
+	}
+	if err := scanner.Err(); err != nil {
+		return Checksums{}, err
+	}
+
+	slices.Sort(result.filenames)
+	return result, nil
+}
+
+// Len returns the number of files listed.
+func (c Checksums) Len() int {
+	return len(c.filenames)
+}
+
+// Lookup returns the checksum of the named file, and whether it is listed.
+func (c Checksums) Lookup(filename string) (Checksum, bool) {
+	sum, ok := c.sums[filename]
+	return sum, ok
+}
+
+// LookupBuild returns the checksum of the file from which build may be downloaded, and whether it
+// is listed.
+func (c Checksums) LookupBuild(build BuildInfo) (Checksum, bool) {
+	parsed, err := url.Parse(build.URL)
+	if err != nil || parsed.Path == "" {
+		return Checksum{}, false
+	}
+	return c.Lookup(path.Base(parsed.Path))
+}
+
+// All returns an iterator over each listed file and its checksum, ordered by filename.
+func (c Checksums) All() iter.Seq2[string, Checksum] {
+	return func(yield func(string, Checksum) bool) {
+		for _, filename := range c.filenames {
+			if !yield(filename, c.sums[filename]) {
+				return
+			}
+		}
+	}
+}
+
+// WriteTo writes the checksums in the canonical SHA256SUMS format, ordered by filename.
+func (c Checksums) WriteTo(w io.Writer) (int64, error) {
+	var written int64
+	for filename, sum := range c.All() {
+		n, err := fmt.Fprintf(w, "%s  %s\n", sum.Hex(), filename)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+func (c Checksums) String() string {
+	var b strings.Builder
+	_, _ = c.WriteTo(&b)
+	return b.String()
+}
+
+// Checksums retrieves and parses the SHA256SUMS file referenced by release.URLSHASUMs, rewritten
+// according to any rules configured using WithURLRewrites. If the release does not reference one,
+// ErrNoChecksums is returned. If the release has been withdrawn, a *ReleaseWithdrawnError is
+// returned, unless the Client was constructed using WithAllowWithdrawn.
+//
+// Note that the signatures of the file are not verified.
+func (c *Client) Checksums(ctx context.Context, release ReleaseInfo) (Checksums, error) {
+	if err := c.checkWithdrawn(release); err != nil {
+		return Checksums{}, err
+	}
+	if release.URLSHASUMs == "" {
+		return Checksums{}, fmt.Errorf("%w: %s %s", ErrNoChecksums, release.Name, release.Version)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.RewriteURL(release.URLSHASUMs), nil)
+	if err != nil {
+		return Checksums{}, fmt.Errorf("%w: %w", ErrConstructingRequest, err)
+	}
+	if c.opts.userAgent != nil {
+		req.Header.Set("User-Agent", *c.opts.userAgent)
+	}
+
+	resp, err := c.opts.httpClient.Do(req)
+	if err != nil {
+		return Checksums{}, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return Checksums{}, fmt.Errorf("%w: %d", ErrInvalidStatusCode, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxChecksumsBytes+1))
+	if err != nil {
+		return Checksums{}, err
+	}
+	if len(body) > maxChecksumsBytes {
+		return Checksums{}, fmt.Errorf("%w: %s exceeds %d bytes", ErrInvalidChecksums, release.URLSHASUMs, maxChecksumsBytes)
+	}
+
+	return ParseChecksums(bytes.NewReader(body))
+}
+
+// HashPackageH1 returns the "h1:" hash of the zip archive read from r, which Terraform records in
+// dependency lock files alongside "zh:" hashes. Unlike those, it is computed from the contents of
+// the archive rather than the archive itself, and so cannot be derived from a SHA256SUMS file.
+func HashPackageH1(r io.ReaderAt, size int64) (string, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return "", err
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	names := make([]string, 0, len(archive.File))
+	for _, file := range archive.File {
+		if strings.Contains(file.Name, "\n") {
+			return "", fmt.Errorf("archive contains a filename with a newline: %q", file.Name)
+		}
+		files[file.Name] = file
+		names = append(names, file.Name)
+	}
+	slices.Sort(names)
+
+	summary := sha256.New()
+	for _, name := range names {
+		sum, err := hashZipFile(files[name])
+		if err != nil {
+			return "", err
+		}
+		_, _ = fmt.Fprintf(summary, "%x  %s\n", sum, name)
+	}
+
+	return "h1:" + base64.StdEncoding.EncodeToString(summary.Sum(nil)), nil
+}
+
+func hashZipFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, rc); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
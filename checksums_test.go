@@ -0,0 +1,145 @@
+package releases_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+// The checksum of "hello\n".
+const testChecksumHex = "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03"
+
+const testChecksums = `0a5a3d3e1e1d07b1e9f3c3d2cd3d1b39bde0b4a0f1a8a2f2b2d5e7a1a0b2c3d4  waypoint_0.11.4_linux_amd64.zip
+5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03 *waypoint_0.11.4_darwin_arm64.zip
+
+5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03  waypoint_0.11.4_darwin_arm64.zip
+`
+
+func TestChecksum_Formats(t *testing.T) {
+	sum, err := releases.ParseChecksum(testChecksumHex)
+	requireNoError(t, err)
+
+	requireEqual(t, testChecksumHex, sum.Hex())
+	requireEqual(t, testChecksumHex, sum.String())
+	requireEqual(t, "zh:"+testChecksumHex, sum.ZH())
+	requireEqual(t, "sha256-WJG1tSLV3whtD/CxEPvZ0hu0/HFjrzTQgoai6Eb2vgM=", sum.SRI())
+
+	for _, form := range []string{sum.ZH(), sum.SRI()} {
+		parsed, err := releases.ParseChecksum(form)
+		requireNoError(t, err)
+		requireEqual(t, sum, parsed)
+	}
+
+	for _, invalid := range []string{"", "zh:abcd", "sha256-!!", "h1:WJG1tSLV3whtD/CxEPvZ0hu0/HFjrzTQgoai6Eb2vgM="} {
+		if _, err := releases.ParseChecksum(invalid); !errors.Is(err, releases.ErrInvalidChecksums) {
+			t.Fatalf("expected ErrInvalidChecksums for %q, got: %v", invalid, err)
+		}
+	}
+}
+
+func TestParseChecksums(t *testing.T) {
+	checksums, err := releases.ParseChecksums(strings.NewReader(testChecksums))
+	requireNoError(t, err)
+	requireEqual(t, 2, checksums.Len())
+
+	sum, ok := checksums.Lookup("waypoint_0.11.4_darwin_arm64.zip")
+	requireEqual(t, true, ok)
+	requireEqual(t, testChecksumHex, sum.Hex())
+
+	_, ok = checksums.Lookup("waypoint_0.11.4_windows_amd64.zip")
+	requireEqual(t, false, ok)
+
+	sum, ok = checksums.LookupBuild(releases.BuildInfo{
+		OS:   "darwin",
+		Arch: "arm64",
+		URL:  "https://releases.hashicorp.com/waypoint/0.11.4/waypoint_0.11.4_darwin_arm64.zip?download=1",
+	})
+	requireEqual(t, true, ok)
+	requireEqual(t, testChecksumHex, sum.Hex())
+
+	var filenames []string
+	for filename := range checksums.All() {
+		filenames = append(filenames, filename)
+		break
+	}
+	requireEqual(t, []string{"waypoint_0.11.4_darwin_arm64.zip"}, filenames)
+
+	expected := testChecksumHex + "  waypoint_0.11.4_darwin_arm64.zip\n" +
+		"0a5a3d3e1e1d07b1e9f3c3d2cd3d1b39bde0b4a0f1a8a2f2b2d5e7a1a0b2c3d4  waypoint_0.11.4_linux_amd64.zip\n"
+	requireEqual(t, expected, checksums.String())
+
+	reparsed, err := releases.ParseChecksums(strings.NewReader(checksums.String()))
+	requireNoError(t, err)
+	requireEqual(t, checksums, reparsed)
+}
+
+func TestParseChecksums_Invalid(t *testing.T) {
+	testCases := map[string]string{
+		"No Filename":        testChecksumHex + "\n",
+		"Single Space":       testChecksumHex + " waypoint.zip\n",
+		"Short Checksum":     "abcd  waypoint.zip\n",
+		"Conflicting Sums":   testChecksumHex + "  waypoint.zip\n" + strings.Repeat("0", 64) + "  waypoint.zip\n",
+		"Not Hex":            strings.Repeat("z", 64) + "  waypoint.zip\n",
+		"Signature Contents": "-----BEGIN PGP SIGNATURE-----\n",
+	}
+
+	for name, input := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := releases.ParseChecksums(strings.NewReader(input)); !errors.Is(err, releases.ErrInvalidChecksums) {
+				t.Fatalf("expected ErrInvalidChecksums, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestClient_Checksums(t *testing.T) {
+	body := testChecksums
+	client, err := releases.New(releases.WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requireEqual(t, waypoint_0_11_4.URLSHASUMs, req.URL.String())
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}),
+	}))
+	requireNoError(t, err)
+
+	checksums, err := client.Checksums(context.Background(), waypoint_0_11_4)
+	requireNoError(t, err)
+	requireEqual(t, 2, checksums.Len())
+
+	body = strings.Repeat(testChecksums, 1024*1024/len(testChecksums)+1)
+	if _, err := client.Checksums(context.Background(), waypoint_0_11_4); !errors.Is(err, releases.ErrInvalidChecksums) || !strings.Contains(err.Error(), "exceeds") {
+		t.Fatalf("expected ErrInvalidChecksums for an oversized file, got: %v", err)
+	}
+
+	withoutChecksums := waypoint_0_11_4
+	withoutChecksums.URLSHASUMs = ""
+	if _, err := client.Checksums(context.Background(), withoutChecksums); !errors.Is(err, releases.ErrNoChecksums) {
+		t.Fatalf("expected ErrNoChecksums, got: %v", err)
+	}
+}
+
+func TestHashPackageH1(t *testing.T) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, name := range []string{"terraform-provider-null", "LICENSE"} {
+		w, err := archive.Create(name)
+		requireNoError(t, err)
+		_, err = io.WriteString(w, "hello\n")
+		requireNoError(t, err)
+	}
+	requireNoError(t, archive.Close())
+
+	hash, err := releases.HashPackageH1(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	requireNoError(t, err)
+	requireEqual(t, "h1:SooMMQUg2ppB0uxIA4SorNiZ0JlpOoIHTE+Ybi3a8ic=", hash)
+}
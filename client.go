@@ -1,14 +1,19 @@
 package releases
 
 import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
-	"path"
+	"time"
 )
 
 var (
-	defaultUserAgent = "go-hashicorp-releases-client"
-	defaultBaseURL   = url.URL{
+	defaultUserAgent   = "go-hashicorp-releases-client"
+	defaultConcurrency = 4
+	defaultBaseURL     = url.URL{
 		Scheme: "https",
 		Host:   "api.releases.hashicorp.com",
 	}
@@ -19,7 +24,8 @@ type ClientOpt func(*clientOpts) error
 
 // Client provides a handle to interact with the HashiCorp Releases API.
 type Client struct {
-	opts clientOpts
+	opts   clientOpts
+	health *endpointHealth
 }
 
 // New creates a new Client, and uses the supplied options to configure it.
@@ -29,29 +35,57 @@ func New(opts ...ClientOpt) (*Client, error) {
 		return nil, err
 	}
 
+	effectiveOpts.httpClient, err = effectiveOpts.authenticatedHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Client{
-		opts: effectiveOpts,
+		opts:   effectiveOpts,
+		health: newEndpointHealth(),
 	}, nil
 }
 
-func (c *Client) makeURL(pathComponents string, query url.Values) url.URL {
-	result := c.opts.baseURL
-	result.Path = path.Join(result.Path, pathComponents)
-	result.RawQuery = query.Encode()
-	return result
+// newDecoder returns a json.Decoder reading from r. If the Client was constructed using
+// WithStrictDecoding, the decoder rejects unknown fields. Note that unknown fields of ReleaseInfo
+// and its constituent types are instead retained, returned by their Extra methods, and reported by
+// Validate.
+func (c *Client) newDecoder(r io.Reader) *json.Decoder {
+	dec := json.NewDecoder(r)
+	if c.opts.strictDecoding {
+		dec.DisallowUnknownFields()
+	}
+	return dec
 }
 
 type clientOpts struct {
-	httpClient *http.Client
-	userAgent  *string
-	baseURL    url.URL
+	httpClient       *http.Client
+	userAgent        *string
+	baseURL          url.URL
+	fallbackURLs     []url.URL
+	endpointCooldown time.Duration
+	concurrency      int
+	allowWithdrawn   bool
+	strictDecoding   bool
+	keyRing          *KeyRing
+	retries          int
+
+	authHeader         http.Header
+	tokenSource        TokenSource
+	basicAuth          *basicAuth
+	clientCertificates []tls.Certificate
+	credentialHosts    []string
+	rewriteRules       []rewriteRule
 }
 
 func newClientOpts(opts ...ClientOpt) (clientOpts, error) {
 	effectiveOpts := clientOpts{
-		httpClient: http.DefaultClient,
-		userAgent:  &defaultUserAgent,
-		baseURL:    defaultBaseURL,
+		httpClient:       http.DefaultClient,
+		userAgent:        &defaultUserAgent,
+		baseURL:          defaultBaseURL,
+		concurrency:      defaultConcurrency,
+		keyRing:          DefaultKeyRing(),
+		endpointCooldown: defaultEndpointCooldown,
 	}
 
 	for _, opt := range opts {
@@ -107,6 +141,122 @@ func WithBaseURL(baseURL string) ClientOpt {
 			return err
 		}
 		opts.baseURL = *parsed
+		opts.fallbackURLs = nil
+		return nil
+	}
+}
+
+// WithBaseURLs sets the URL at which the root of the releases API may be reached, along with
+// fallback URLs, such as internal mirrors, to use if it is unavailable.
+//
+// Each request is made to the first endpoint believed to be healthy, in the order supplied. If it
+// fails with a network error or a status code indicating a transient failure, the endpoint is
+// considered unhealthy for the cooldown period set using WithEndpointCooldown, and the request is
+// made to the next endpoint. If every endpoint is unhealthy, they are tried in the order in which
+// they are expected to recover. All pages of a call to ReleasesPaged or Releases are requested
+// from the endpoint which served the first page. WithCallServedBy may be used to determine which
+// endpoint served each response.
+func WithBaseURLs(primary string, fallbacks ...string) ClientOpt {
+	return func(opts *clientOpts) error {
+		parsed, err := url.Parse(primary)
+		if err != nil {
+			return err
+		}
+
+		fallbackURLs := make([]url.URL, 0, len(fallbacks))
+		for _, fallback := range fallbacks {
+			parsedFallback, err := url.Parse(fallback)
+			if err != nil {
+				return err
+			}
+			fallbackURLs = append(fallbackURLs, *parsedFallback)
+		}
+
+		opts.baseURL = *parsed
+		opts.fallbackURLs = fallbackURLs
+		return nil
+	}
+}
+
+// WithEndpointCooldown sets the period for which an endpoint configured using WithBaseURLs is
+// avoided after a request to it fails. If unset, the period is 30 seconds. Values which are not
+// positive are rejected.
+func WithEndpointCooldown(cooldown time.Duration) ClientOpt {
+	return func(opts *clientOpts) error {
+		if cooldown <= 0 {
+			return fmt.Errorf("%w: must be positive, got %s", ErrInvalidInterval, cooldown)
+		}
+		opts.endpointCooldown = cooldown
+		return nil
+	}
+}
+
+// WithConcurrency sets the maximum number of requests which may be in flight at once for functions
+// which fan out over several products or versions, such as LatestReleases.
+//
+// If unset, at most 4 requests are made concurrently. Values less than 1 are rejected.
+func WithConcurrency(concurrency int) ClientOpt {
+	return func(opts *clientOpts) error {
+		if err := validateConcurrency(concurrency); err != nil {
+			return err
+		}
+		opts.concurrency = concurrency
+		return nil
+	}
+}
+
+// WithRetries sets the number of times a request to the releases API is retried if it fails with a
+// network error or a status code indicating a transient failure, which are "429 Too Many Requests",
+// "500 Internal Server Error", "502 Bad Gateway", "503 Service Unavailable" and "504 Gateway
+// Timeout". Retries are delayed as directed by any Retry-After header, and otherwise with
+// exponential backoff. The setting may be overridden for a single call using WithCallRetries.
+//
+// If unset, requests are not retried. Values less than 0 are rejected.
+func WithRetries(retries int) ClientOpt {
+	return func(opts *clientOpts) error {
+		if retries < 0 {
+			return fmt.Errorf("%w: must be at least 0, got %d", ErrInvalidRetries, retries)
+		}
+		opts.retries = retries
+		return nil
+	}
+}
+
+// WithAllowWithdrawn permits functions which select a release to use, such as LatestRelease, or
+// which retrieve its artifacts, namely Checksums, Changelog and SelectSignature, to operate on
+// releases which have been withdrawn without error.
+//
+// If this option is not supplied, such functions return a *ReleaseWithdrawnError when the release
+// has been withdrawn. Functions which return metadata about a specific version, such as Release,
+// are not affected.
+func WithAllowWithdrawn() ClientOpt {
+	return func(opts *clientOpts) error {
+		opts.allowWithdrawn = true
+		return nil
+	}
+}
+
+// WithStrictDecoding causes every response to be checked strictly against the documented API, in
+// order to detect changes to the API at the earliest opportunity. In particular, the Content-Type
+// of every response is checked, unknown fields are rejected, and each release is checked using
+// ReleaseInfo.Validate. Violations are reported as a *ValidationError.
+//
+// This option is intended for canary jobs and tests. Applications should not use it, since any
+// backwards-compatible extension of the API will cause requests to fail.
+func WithStrictDecoding() ClientOpt {
+	return func(opts *clientOpts) error {
+		opts.strictDecoding = true
+		return nil
+	}
+}
+
+// WithKeyRing sets the trusted signing keys used to select signatures, replacing those returned by
+// DefaultKeyRing. If keyRing is nil, DefaultKeyRing is used.
+func WithKeyRing(keyRing *KeyRing) ClientOpt {
+	return func(opts *clientOpts) error {
+		if keyRing != nil {
+			opts.keyRing = keyRing
+		}
 		return nil
 	}
 }
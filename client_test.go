@@ -1,9 +1,11 @@
 package releases
 
 import (
+	"errors"
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 )
 
 func TestWithHTTPClient(t *testing.T) {
@@ -100,3 +102,156 @@ func TestWithBaseURL(t *testing.T) {
 		}
 	})
 }
+
+func TestWithConcurrency(t *testing.T) {
+	t.Run("Overridden Concurrency", func(t *testing.T) {
+		clientOpts, err := newClientOpts(WithConcurrency(8))
+		if err != nil {
+			t.Fatalf("Error applying option: %v", err)
+		}
+
+		if clientOpts.concurrency != 8 {
+			t.Fatalf("WithConcurrency must set concurrency option to 8, was %d", clientOpts.concurrency)
+		}
+	})
+
+	t.Run("Default Concurrency", func(t *testing.T) {
+		clientOpts, err := newClientOpts()
+		if err != nil {
+			t.Fatalf("Error applying option: %v", err)
+		}
+
+		if clientOpts.concurrency != defaultConcurrency {
+			t.Fatalf("Default concurrency must be %d, was %d", defaultConcurrency, clientOpts.concurrency)
+		}
+	})
+
+	t.Run("Overridden Invalid Concurrency", func(t *testing.T) {
+		_, err := newClientOpts(WithConcurrency(0))
+		if !errors.Is(err, ErrInvalidConcurrency) {
+			t.Fatalf("Concurrency of zero must produce ErrInvalidConcurrency, got %v", err)
+		}
+	})
+}
+
+func TestWithAllowWithdrawn(t *testing.T) {
+	clientOpts, err := newClientOpts(WithAllowWithdrawn())
+	if err != nil {
+		t.Fatalf("Error applying option: %v", err)
+	}
+
+	if !clientOpts.allowWithdrawn {
+		t.Fatal("WithAllowWithdrawn must set allow withdrawn option")
+	}
+}
+
+func TestWithStrictDecoding(t *testing.T) {
+	clientOpts, err := newClientOpts(WithStrictDecoding())
+	if err != nil {
+		t.Fatalf("Error applying option: %v", err)
+	}
+
+	if !clientOpts.strictDecoding {
+		t.Fatal("WithStrictDecoding must set strict decoding option")
+	}
+}
+
+func TestWithKeyRing(t *testing.T) {
+	t.Run("Overridden Key Ring", func(t *testing.T) {
+		testKeyRing, err := NewKeyRing()
+		if err != nil {
+			t.Fatalf("Error constructing key ring: %v", err)
+		}
+
+		clientOpts, err := newClientOpts(WithKeyRing(testKeyRing))
+		if err != nil {
+			t.Fatalf("Error applying option: %v", err)
+		}
+
+		if clientOpts.keyRing != testKeyRing {
+			t.Fatal("WithKeyRing must set key ring option to supplied value")
+		}
+	})
+
+	t.Run("Nil Key Ring", func(t *testing.T) {
+		clientOpts, err := newClientOpts(WithKeyRing(nil))
+		if err != nil {
+			t.Fatalf("Error applying option: %v", err)
+		}
+
+		if len(clientOpts.keyRing.Keys()) == 0 {
+			t.Fatal("WithKeyRing must use the default key ring if supplied value is nil")
+		}
+	})
+}
+
+func TestWithRetries(t *testing.T) {
+	t.Run("Overridden Retries", func(t *testing.T) {
+		clientOpts, err := newClientOpts(WithRetries(3))
+		if err != nil {
+			t.Fatalf("Error applying option: %v", err)
+		}
+
+		if clientOpts.retries != 3 {
+			t.Fatalf("WithRetries must set retries option to 3, was %d", clientOpts.retries)
+		}
+	})
+
+	t.Run("Overridden Invalid Retries", func(t *testing.T) {
+		_, err := newClientOpts(WithRetries(-1))
+		if !errors.Is(err, ErrInvalidRetries) {
+			t.Fatalf("Negative retries must produce ErrInvalidRetries, got %v", err)
+		}
+	})
+}
+
+func TestWithBaseURLs(t *testing.T) {
+	t.Run("Overridden Base URLs", func(t *testing.T) {
+		clientOpts, err := newClientOpts(WithBaseURLs("https://primary.example.com", "https://fallback.example.com"))
+		if err != nil {
+			t.Fatalf("Error applying option: %v", err)
+		}
+
+		if clientOpts.baseURL.String() != "https://primary.example.com" {
+			t.Fatalf("WithBaseURLs must set Base URL option to primary, was %q", clientOpts.baseURL.String())
+		}
+		if len(clientOpts.fallbackURLs) != 1 || clientOpts.fallbackURLs[0].String() != "https://fallback.example.com" {
+			t.Fatalf("WithBaseURLs must set fallback URLs option, was %v", clientOpts.fallbackURLs)
+		}
+	})
+
+	t.Run("Base URL Replaces Fallbacks", func(t *testing.T) {
+		clientOpts, err := newClientOpts(
+			WithBaseURLs("https://primary.example.com", "https://fallback.example.com"),
+			WithBaseURL("https://api.example.com"))
+		if err != nil {
+			t.Fatalf("Error applying option: %v", err)
+		}
+
+		if len(clientOpts.fallbackURLs) != 0 {
+			t.Fatalf("WithBaseURL must clear fallback URLs option, was %v", clientOpts.fallbackURLs)
+		}
+	})
+
+	t.Run("Overridden Invalid URL", func(t *testing.T) {
+		_, err := newClientOpts(WithBaseURLs("https://primary.example.com", "localhost\x00"))
+		if err == nil {
+			t.Fatal("Invalid fallback URL must produce error during option application")
+		}
+	})
+}
+
+func TestWithEndpointCooldown(t *testing.T) {
+	clientOpts, err := newClientOpts(WithEndpointCooldown(time.Minute))
+	if err != nil {
+		t.Fatalf("Error applying option: %v", err)
+	}
+
+	if clientOpts.endpointCooldown != time.Minute {
+		t.Fatalf("WithEndpointCooldown must set cooldown option to 1m, was %s", clientOpts.endpointCooldown)
+	}
+
+	if _, err := newClientOpts(WithEndpointCooldown(0)); !errors.Is(err, ErrInvalidInterval) {
+		t.Fatalf("Cooldown of zero must produce ErrInvalidInterval, got %v", err)
+	}
+}
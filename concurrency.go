@@ -0,0 +1,39 @@
+package releases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// validateConcurrency returns an error wrapping ErrInvalidConcurrency if concurrency is less than 1.
+func validateConcurrency(concurrency int) error {
+	if concurrency < 1 {
+		return fmt.Errorf("%w: must be at least 1, got %d", ErrInvalidConcurrency, concurrency)
+	}
+	return nil
+}
+
+// forEachConcurrently calls fn for each element of items, with at most limit calls in progress at
+// once. It returns once every call has completed.
+func forEachConcurrently[T any](ctx context.Context, limit int, items []T, fn func(context.Context, T)) {
+	work := make(chan T)
+
+	var wg sync.WaitGroup
+	for range min(limit, len(items)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range work {
+				fn(ctx, item)
+			}
+		}()
+	}
+
+	for _, item := range items {
+		work <- item
+	}
+	close(work)
+
+	wg.Wait()
+}
@@ -0,0 +1,63 @@
+package releases
+
+import (
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+)
+
+const defaultEndpointCooldown = 30 * time.Second
+
+// endpointHealth tracks endpoints of the releases API which have recently failed, so that requests
+// prefer endpoints which are believed to be healthy until the cooldown period has elapsed.
+type endpointHealth struct {
+	mu             sync.Mutex
+	unhealthyUntil map[string]time.Time
+}
+
+func newEndpointHealth() *endpointHealth {
+	return &endpointHealth{
+		unhealthyUntil: make(map[string]time.Time),
+	}
+}
+
+// order returns endpoints in the order in which they should be tried: those believed to be healthy
+// in their configured order, followed by those in their cooldown period, soonest to recover first.
+func (h *endpointHealth) order(endpoints []url.URL) []url.URL {
+	if len(endpoints) < 2 {
+		return endpoints
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	var healthy, unhealthy []url.URL
+	for _, endpoint := range endpoints {
+		if until, ok := h.unhealthyUntil[endpoint.String()]; ok && now.Before(until) {
+			unhealthy = append(unhealthy, endpoint)
+		} else {
+			healthy = append(healthy, endpoint)
+		}
+	}
+
+	slices.SortStableFunc(unhealthy, func(a, b url.URL) int {
+		return h.unhealthyUntil[a.String()].Compare(h.unhealthyUntil[b.String()])
+	})
+	return append(healthy, unhealthy...)
+}
+
+func (h *endpointHealth) markFailed(endpoint url.URL, cooldown time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.unhealthyUntil[endpoint.String()] = time.Now().Add(cooldown)
+}
+
+func (h *endpointHealth) markHealthy(endpoint url.URL) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.unhealthyUntil, endpoint.String())
+}
@@ -0,0 +1,146 @@
+package releases_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+// newTestEndpoint returns a server which serves the test releases unless failing is set, in which
+// case it returns "503 Service Unavailable". The number of requests received is counted.
+func newTestEndpoint(t *testing.T, failing *atomic.Bool, requests *atomic.Int32) *httptest.Server {
+	handler := makeTestReleasesHandler(t)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+}
+
+func TestWithBaseURLs_Failover(t *testing.T) {
+	var primaryFailing, fallbackFailing atomic.Bool
+	var primaryRequests, fallbackRequests atomic.Int32
+
+	primary := newTestEndpoint(t, &primaryFailing, &primaryRequests)
+	defer primary.Close()
+	fallback := newTestEndpoint(t, &fallbackFailing, &fallbackRequests)
+	defer fallback.Close()
+
+	client, err := releases.New(
+		releases.WithBaseURLs(primary.URL, fallback.URL),
+		releases.WithEndpointCooldown(100*time.Millisecond))
+	requireNoError(t, err)
+
+	var servedBy string
+	release := func() {
+		t.Helper()
+		result, err := client.Release(context.Background(), "waypoint", "0.1.0",
+			releases.WithCallServedBy(func(endpoint string) { servedBy = endpoint }))
+		requireNoError(t, err)
+		requireEqual(t, waypoint_0_1_0, result)
+	}
+
+	release()
+	requireEqual(t, primary.URL, servedBy)
+
+	primaryFailing.Store(true)
+	release()
+	requireEqual(t, fallback.URL, servedBy)
+	requireEqual(t, int32(2), primaryRequests.Load())
+
+	// The primary endpoint is not retried until its cooldown period has elapsed.
+	primaryFailing.Store(false)
+	release()
+	requireEqual(t, fallback.URL, servedBy)
+	requireEqual(t, int32(2), primaryRequests.Load())
+
+	time.Sleep(150 * time.Millisecond)
+	release()
+	requireEqual(t, primary.URL, servedBy)
+
+	// If every endpoint fails, the last response is returned.
+	primaryFailing.Store(true)
+	fallbackFailing.Store(true)
+	_, err = client.Release(context.Background(), "waypoint", "0.1.0")
+	if !errors.Is(err, releases.ErrInvalidStatusCode) {
+		t.Fatalf("expected ErrInvalidStatusCode, got: %v", err)
+	}
+}
+
+func TestWithBaseURLs_Unreachable(t *testing.T) {
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	var failing atomic.Bool
+	var requests atomic.Int32
+	fallback := newTestEndpoint(t, &failing, &requests)
+	defer fallback.Close()
+
+	client, err := releases.New(releases.WithBaseURLs(unreachable.URL, fallback.URL))
+	requireNoError(t, err)
+
+	release, err := client.LatestRelease(context.Background(), "waypoint", releases.LicenseClassOSS)
+	requireNoError(t, err)
+	requireEqual(t, waypoint_0_11_4, release)
+}
+
+func TestWithBaseURLs_NotFound(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+
+	primary := httptest.NewServer(http.NotFoundHandler())
+	defer primary.Close()
+	fallback := newTestEndpoint(t, &failing, &requests)
+	defer fallback.Close()
+
+	client, err := releases.New(releases.WithBaseURLs(primary.URL, fallback.URL))
+	requireNoError(t, err)
+
+	_, err = client.Release(context.Background(), "waypoint", "0.1.0")
+	if !errors.Is(err, releases.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+	requireEqual(t, int32(0), requests.Load())
+}
+
+func TestWithBaseURLs_PaginationPinned(t *testing.T) {
+	var primaryFailing, fallbackFailing atomic.Bool
+	var primaryRequests, fallbackRequests atomic.Int32
+
+	primary := newTestEndpoint(t, &primaryFailing, &primaryRequests)
+	defer primary.Close()
+	fallback := newTestEndpoint(t, &fallbackFailing, &fallbackRequests)
+	defer fallback.Close()
+
+	client, err := releases.New(releases.WithBaseURLs(primary.URL, fallback.URL))
+	requireNoError(t, err)
+
+	var servedBy []string
+	pages, err := client.ReleasesPaged(context.Background(), "waypoint", nil,
+		releases.WithCallServedBy(func(endpoint string) { servedBy = append(servedBy, endpoint) }))
+	requireNoError(t, err)
+
+	var pageErr error
+	for _, err := range pages {
+		if err != nil {
+			pageErr = err
+			break
+		}
+		primaryFailing.Store(true)
+	}
+
+	if !errors.Is(pageErr, releases.ErrInvalidStatusCode) {
+		t.Fatalf("expected ErrInvalidStatusCode once the pinned endpoint failed, got: %v", pageErr)
+	}
+	requireEqual(t, []string{primary.URL, primary.URL}, servedBy)
+	requireEqual(t, int32(0), fallbackRequests.Load())
+}
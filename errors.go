@@ -2,6 +2,10 @@ package releases
 
 import (
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 )
 
 var (
@@ -9,6 +13,10 @@ var (
 	// This is usually because the value is empty.
 	ErrInvalidProduct = errors.New("invalid product")
 
+	// ErrInvalidVersion indicates that a version supplied as a parameter is invalid. This is
+	// usually because the value is empty.
+	ErrInvalidVersion = errors.New("invalid version")
+
 	// ErrInvalidLicenseClass indicates that a license class supplied as a parameter is
 	// invalid. Note that package-level instance variables are provided for all valid license
 	// classes, which may be used instead of string values.
@@ -26,6 +34,154 @@ var (
 	// structure.
 	ErrInvalidResponseBody = errors.New("invalid response body")
 
+	// ErrTiedReleasesExceedPage indicates that more releases of a product share a creation
+	// timestamp than the API returns in a single page, so they cannot all be retrieved.
+	ErrTiedReleasesExceedPage = errors.New("tied releases exceed page")
+
+	// ErrInvalidInterval indicates that a polling interval or cooldown period supplied as a
+	// parameter is invalid. This is usually because the value is not positive.
+	ErrInvalidInterval = errors.New("invalid interval")
+
+	// ErrInvalidConcurrency indicates that a concurrency limit supplied as an option or parameter is
+	// invalid. This is usually because the value is less than 1.
+	ErrInvalidConcurrency = errors.New("invalid concurrency")
+
+	// ErrInvalidTimeout indicates that a timeout supplied as an option is invalid. This is usually
+	// because the value is not positive.
+	ErrInvalidTimeout = errors.New("invalid timeout")
+
+	// ErrInvalidRetries indicates that a number of retries supplied as an option is invalid.
+	ErrInvalidRetries = errors.New("invalid retries")
+
+	// ErrInvalidCredentials indicates that credentials supplied as an option are invalid.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+
+	// ErrTokenSource indicates that a TokenSource failed to supply a bearer token. The cause is
+	// wrapped.
+	ErrTokenSource = errors.New("failed to obtain bearer token")
+
+	// ErrInvalidRewriteRule indicates that a URL rewrite rule supplied as an option is invalid.
+	ErrInvalidRewriteRule = errors.New("invalid URL rewrite rule")
+
 	// ErrInvalidStatusCode indicates that the server returned a status code other than "200 OK".
 	ErrInvalidStatusCode = errors.New("invalid response status code")
+
+	// ErrReleaseWithdrawn indicates that a release has been withdrawn, and may not be used without
+	// explicitly opting in. Errors wrapping it are of type *ReleaseWithdrawnError, which carries the
+	// reason for the withdrawal.
+	ErrReleaseWithdrawn = errors.New("release withdrawn")
+
+	// ErrNoChangelog indicates that a release does not reference a changelog.
+	ErrNoChangelog = errors.New("release has no changelog")
+
+	// ErrChangelogVersionNotFound indicates that a changelog does not contain a section for the
+	// version of a release.
+	ErrChangelogVersionNotFound = errors.New("version not found in changelog")
+
+	// ErrNoImage indicates that a release does not reference a container image in the requested
+	// registry.
+	ErrNoImage = errors.New("release has no container image")
+
+	// ErrInvalidImageRef indicates that a container image reference could not be parsed.
+	ErrInvalidImageRef = errors.New("invalid image reference")
+
+	// ErrNoChecksums indicates that a release does not reference a SHA256SUMS file.
+	ErrNoChecksums = errors.New("release has no checksums")
+
+	// ErrInvalidChecksums indicates that a SHA256SUMS file, or a checksum, could not be parsed.
+	ErrInvalidChecksums = errors.New("invalid checksums")
+
+	// ErrInvalidSigningKey indicates that a signing key supplied for a KeyRing is invalid.
+	ErrInvalidSigningKey = errors.New("invalid signing key")
+
+	// ErrNoTrustedKey indicates that no signature of a release is made by a trusted key which
+	// covers the time at which the release was created.
+	ErrNoTrustedKey = errors.New("no trusted signing key")
+
+	// ErrNoBaseline indicates that Watch has not yet established which releases are already known,
+	// since each poll so far has failed. Releases published before the first successful poll are
+	// not reported.
+	ErrNoBaseline = errors.New("no baseline established")
+
+	// ErrNotFound indicates that the server returned "404 Not Found" for the requested product or
+	// release, or that a Snapshot does not contain it. When returned by Client, it is always
+	// accompanied by ErrInvalidStatusCode.
+	ErrNotFound = errors.New("not found")
 )
+
+// ProductError wraps an error encountered while retrieving release information for a single
+// product, as part of an operation which spans several products.
+type ProductError struct {
+	// Product is the name of the product for which the error was encountered.
+	Product string
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ProductError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Product, e.Err)
+}
+
+func (e *ProductError) Unwrap() error {
+	return e.Err
+}
+
+// ReleasesByVersionError is returned by ReleasesByVersion when metadata for one or more of the
+// requested versions could not be retrieved.
+type ReleasesByVersionError struct {
+	// Product is the name of the product for which releases were requested.
+	Product string
+
+	// NotFound lists the requested versions for which no release exists, in the order in which they
+	// were requested.
+	NotFound []string
+
+	// Errors maps any other requested versions which could not be retrieved to the error encountered.
+	Errors map[string]error
+}
+
+func (e *ReleasesByVersionError) Error() string {
+	var parts []string
+	if len(e.NotFound) > 0 {
+		parts = append(parts, fmt.Sprintf("versions not found: %s", strings.Join(e.NotFound, ", ")))
+	}
+	for _, version := range slices.Sorted(maps.Keys(e.Errors)) {
+		parts = append(parts, fmt.Sprintf("%s: %s", version, e.Errors[version]))
+	}
+	return fmt.Sprintf("%s: %s", e.Product, strings.Join(parts, "; "))
+}
+
+func (e *ReleasesByVersionError) Unwrap() []error {
+	var errs []error
+	if len(e.NotFound) > 0 {
+		errs = append(errs, ErrNotFound)
+	}
+	for _, version := range slices.Sorted(maps.Keys(e.Errors)) {
+		errs = append(errs, e.Errors[version])
+	}
+	return errs
+}
+
+// ReleaseWithdrawnError indicates that a release has been withdrawn. It wraps ErrReleaseWithdrawn.
+type ReleaseWithdrawnError struct {
+	// Product is the name of the product of which the withdrawn release is a version.
+	Product string
+
+	// Version is the version number of the withdrawn release.
+	Version string
+
+	// Message is the explanation supplied with the withdrawal in ReleaseStatus.Message.
+	Message string
+}
+
+func (e *ReleaseWithdrawnError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s %s: %s", e.Product, e.Version, ErrReleaseWithdrawn)
+	}
+	return fmt.Sprintf("%s %s: %s: %s", e.Product, e.Version, ErrReleaseWithdrawn, e.Message)
+}
+
+func (e *ReleaseWithdrawnError) Unwrap() error {
+	return ErrReleaseWithdrawn
+}
@@ -3,11 +3,21 @@ package releases
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"slices"
+
+	"github.com/jen20/go-hashicorp-releases-client/releasestest/replay"
 )
 
 func ExampleClient_Products() {
-	client, err := New()
+	// Responses are replayed from a golden file, so that the example runs offline and its output is
+	// stable. Other programs would use New without options, to reach the production endpoint.
+	replayTransport, err := replay.New("releasestest/testdata/examples.json")
+	if err != nil {
+		panic(err)
+	}
+
+	client, err := New(WithHTTPClient(&http.Client{Transport: replayTransport}))
 	if err != nil {
 		panic(err)
 	}
@@ -25,13 +35,18 @@ func ExampleClient_Products() {
 }
 
 func ExampleClient_ReleasesPaged() {
-	client, err := New()
+	// Responses are replayed from a golden file, so that the example runs offline and its output is
+	// stable. Other programs would use New without options, to reach the production endpoint.
+	replayTransport, err := replay.New("releasestest/testdata/examples.json")
+	if err != nil {
+		panic(err)
+	}
+
+	client, err := New(WithHTTPClient(&http.Client{Transport: replayTransport}))
 	if err != nil {
 		panic(err)
 	}
 
-	// Note that Waypoint as a released binary is discontinued, so the output should be stable even
-	// though we are hitting the production endpoint.
 	releasePages, err := client.ReleasesPaged(context.Background(), "waypoint", LicenseClassOSS)
 	if err != nil {
 		panic(err)
@@ -48,19 +63,24 @@ func ExampleClient_ReleasesPaged() {
 	}
 
 	// Output:
-	// Page 1 has 16 items
-	// Page 2 has 16 items
-	// Page 3 has 11 items
+	// Page 1 has 15 items
+	// Page 2 has 15 items
+	// Page 3 has 13 items
 }
 
 func ExampleClient_Releases() {
-	client, err := New()
+	// Responses are replayed from a golden file, so that the example runs offline and its output is
+	// stable. Other programs would use New without options, to reach the production endpoint.
+	replayTransport, err := replay.New("releasestest/testdata/examples.json")
+	if err != nil {
+		panic(err)
+	}
+
+	client, err := New(WithHTTPClient(&http.Client{Transport: replayTransport}))
 	if err != nil {
 		panic(err)
 	}
 
-	// Note that Waypoint as a released binary is discontinued, so the output should be stable even
-	// though we are hitting the production endpoint.
 	releases, err := client.Releases(context.Background(), "waypoint", LicenseClassOSS)
 	if err != nil {
 		panic(err)
@@ -0,0 +1,41 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"iter"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+// WriteCSV writes a header row followed by a row for each release yielded by items to w, and
+// returns the number of rows written, excluding the header.
+//
+// If items yields an error, the rows written so far are flushed, and the error is returned along
+// with their count. The output is therefore always well-formed CSV, though it may be incomplete.
+func WriteCSV(w io.Writer, items iter.Seq2[releases.ReleaseInfo, error], options ...Opt) (int, error) {
+	effectiveOpts, err := newOpts(options...)
+	if err != nil {
+		return 0, err
+	}
+
+	cw := csv.NewWriter(w)
+
+	header := make([]string, len(effectiveOpts.columns))
+	for i, column := range effectiveOpts.columns {
+		header[i] = string(column)
+	}
+	if err := cw.Write(header); err != nil {
+		return 0, err
+	}
+
+	count, err := effectiveOpts.records(items, func(release releases.ReleaseInfo, build *releases.BuildInfo) error {
+		return cw.Write(effectiveOpts.row(release, build))
+	})
+
+	cw.Flush()
+	if flushErr := cw.Error(); flushErr != nil && err == nil {
+		err = flushErr
+	}
+	return count, err
+}
@@ -0,0 +1,295 @@
+// Package export writes release metadata obtained from the HashiCorp Releases API in tabular
+// formats suitable for import into spreadsheets and data pipelines: CSV and JSON Lines.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+// ErrUnknownColumn indicates that a column supplied to WithColumns is not one of the columns
+// defined by this package.
+var ErrUnknownColumn = errors.New("unknown column")
+
+// Column identifies a field of release metadata which may be exported. Column names match the JSON
+// field names used by the Releases API.
+type Column string
+
+const (
+	// ColumnName is the name of the product, from the "name" member of a release.
+	ColumnName Column = "name"
+
+	// ColumnVersion is the version of the release, from the "version" member.
+	ColumnVersion Column = "version"
+
+	// ColumnLicenseClass is the license class of the release, from the "license_class" member.
+	ColumnLicenseClass Column = "license_class"
+
+	// ColumnIsPrerelease is "true" if the release is a prerelease, from the "is_prerelease" member.
+	ColumnIsPrerelease Column = "is_prerelease"
+
+	// ColumnState is the support state of the release, from the "state" member of "status".
+	ColumnState Column = "state"
+
+	// ColumnStatusMessage explains the support state, from the "message" member of "status".
+	ColumnStatusMessage Column = "status_message"
+
+	// ColumnTimestampCreated is the time at which the release was created, in RFC 3339 format, from
+	// the "timestamp_created" member.
+	ColumnTimestampCreated Column = "timestamp_created"
+
+	// ColumnTimestampUpdated is the time at which the release was last updated, in RFC 3339 format,
+	// from the "timestamp_updated" member.
+	ColumnTimestampUpdated Column = "timestamp_updated"
+
+	// ColumnDockerNameTag is the Docker image name and tag, from the "docker_name_tag" member.
+	ColumnDockerNameTag Column = "docker_name_tag"
+
+	// ColumnURLBlogpost is the URL of the announcement, from the "url_blogpost" member.
+	ColumnURLBlogpost Column = "url_blogpost"
+
+	// ColumnURLChangelog is the URL of the changelog, from the "url_changelog" member.
+	ColumnURLChangelog Column = "url_changelog"
+
+	// ColumnURLDockerRegistryDockerhub is the URL of the image on Docker Hub, from the
+	// "url_docker_registry_dockerhub" member.
+	ColumnURLDockerRegistryDockerhub Column = "url_docker_registry_dockerhub"
+
+	// ColumnURLDockerRegistryECR is the URL of the image on Amazon ECR, from the
+	// "url_docker_registry_ecr" member.
+	ColumnURLDockerRegistryECR Column = "url_docker_registry_ecr"
+
+	// ColumnURLLicense is the URL of the license, from the "url_license" member.
+	ColumnURLLicense Column = "url_license"
+
+	// ColumnURLProjectWebsite is the URL of the product website, from the "url_project_website"
+	// member.
+	ColumnURLProjectWebsite Column = "url_project_website"
+
+	// ColumnURLReleaseNotes is the URL of the release notes, from the "url_release_notes" member.
+	ColumnURLReleaseNotes Column = "url_release_notes"
+
+	// ColumnURLSHASUMs is the URL of the SHA256SUMS file, from the "url_shasums" member.
+	ColumnURLSHASUMs Column = "url_shasums"
+
+	// ColumnURLSHASUMsSignatures lists the URLs of the signatures of the SHA256SUMS file, separated
+	// by spaces, from the "url_shasums_signatures" member.
+	ColumnURLSHASUMsSignatures Column = "url_shasums_signatures"
+
+	// ColumnURLSourceRepository is the URL of the source repository, from the
+	// "url_source_repository" member.
+	ColumnURLSourceRepository Column = "url_source_repository"
+
+	// ColumnBuilds lists every build of the release, as space-separated "os/arch" pairs.
+	ColumnBuilds Column = "builds"
+
+	// ColumnBuildOS, ColumnBuildArch, ColumnBuildURL and ColumnBuildUnsupported describe a single
+	// build, and are empty unless WithRowPerBuild is supplied.
+	ColumnBuildOS          Column = "build_os"
+	ColumnBuildArch        Column = "build_arch"
+	ColumnBuildURL         Column = "build_url"
+	ColumnBuildUnsupported Column = "build_unsupported"
+)
+
+// DefaultColumns are the columns exported if WithColumns is not supplied. If WithRowPerBuild is
+// supplied, ColumnBuildOS, ColumnBuildArch and ColumnBuildURL are appended.
+var DefaultColumns = []Column{
+	ColumnName,
+	ColumnVersion,
+	ColumnLicenseClass,
+	ColumnIsPrerelease,
+	ColumnState,
+	ColumnTimestampCreated,
+	ColumnTimestampUpdated,
+	ColumnURLSHASUMs,
+}
+
+var allColumns = []Column{
+	ColumnName, ColumnVersion, ColumnLicenseClass, ColumnIsPrerelease, ColumnState, ColumnStatusMessage,
+	ColumnTimestampCreated, ColumnTimestampUpdated, ColumnDockerNameTag, ColumnURLBlogpost, ColumnURLChangelog,
+	ColumnURLDockerRegistryDockerhub, ColumnURLDockerRegistryECR, ColumnURLLicense, ColumnURLProjectWebsite,
+	ColumnURLReleaseNotes, ColumnURLSHASUMs, ColumnURLSHASUMsSignatures, ColumnURLSourceRepository,
+	ColumnBuilds, ColumnBuildOS, ColumnBuildArch, ColumnBuildURL, ColumnBuildUnsupported,
+}
+
+// value returns the value of column for release, and for build if it is not nil.
+func value(column Column, release releases.ReleaseInfo, build *releases.BuildInfo) string {
+	switch column {
+	case ColumnName:
+		return release.Name
+	case ColumnVersion:
+		return release.Version
+	case ColumnLicenseClass:
+		return string(release.LicenseClass)
+	case ColumnIsPrerelease:
+		return strconv.FormatBool(release.IsPrerelease)
+	case ColumnState:
+		return string(release.Status.State)
+	case ColumnStatusMessage:
+		return release.Status.Message
+	case ColumnTimestampCreated:
+		return formatTime(release.TimestampCreated)
+	case ColumnTimestampUpdated:
+		return formatTime(release.TimestampUpdated)
+	case ColumnDockerNameTag:
+		return release.DockerNameTag
+	case ColumnURLBlogpost:
+		return release.URLBlogpost
+	case ColumnURLChangelog:
+		return release.URLChangelog
+	case ColumnURLDockerRegistryDockerhub:
+		return release.URLDockerRegistryDockerhub
+	case ColumnURLDockerRegistryECR:
+		return release.URLDockerRegistryECR
+	case ColumnURLLicense:
+		return release.URLLicense
+	case ColumnURLProjectWebsite:
+		return release.URLProjectWebsite
+	case ColumnURLReleaseNotes:
+		return release.URLReleaseNotes
+	case ColumnURLSHASUMs:
+		return release.URLSHASUMs
+	case ColumnURLSHASUMsSignatures:
+		return strings.Join(release.URLSHASUMsSignatures, " ")
+	case ColumnURLSourceRepository:
+		return release.URLSourceRepository
+	case ColumnBuilds:
+		return formatBuilds(release.Builds)
+	}
+
+	if build == nil {
+		return ""
+	}
+
+	switch column {
+	case ColumnBuildOS:
+		return build.OS
+	case ColumnBuildArch:
+		return build.Arch
+	case ColumnBuildURL:
+		return build.URL
+	case ColumnBuildUnsupported:
+		return strconv.FormatBool(build.Unsupported)
+	default:
+		return ""
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func formatBuilds(builds []releases.BuildInfo) string {
+	parts := make([]string, 0, len(builds))
+	for _, build := range builds {
+		parts = append(parts, build.OS+"/"+build.Arch)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Opt is a functional option which can be used to configure the output of WriteCSV and
+// WriteJSONLines.
+type Opt func(*opts) error
+
+type opts struct {
+	columns     []Column
+	rowPerBuild bool
+	flatten     bool
+}
+
+func newOpts(options ...Opt) (opts, error) {
+	var effectiveOpts opts
+	for _, opt := range options {
+		if err := opt(&effectiveOpts); err != nil {
+			return opts{}, err
+		}
+	}
+
+	if effectiveOpts.columns == nil {
+		effectiveOpts.columns = DefaultColumns
+		if effectiveOpts.rowPerBuild {
+			effectiveOpts.columns = append(DefaultColumns[:len(DefaultColumns):len(DefaultColumns)],
+				ColumnBuildOS, ColumnBuildArch, ColumnBuildURL)
+		}
+	}
+	return effectiveOpts, nil
+}
+
+// WithColumns selects the columns to export, in order. It applies to CSV output and to flattened
+// JSON Lines output. If this option is not supplied, DefaultColumns are exported.
+func WithColumns(columns ...Column) Opt {
+	return func(opts *opts) error {
+		for _, column := range columns {
+			if !slices.Contains(allColumns, column) {
+				return fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+			}
+		}
+		opts.columns = columns
+		return nil
+	}
+}
+
+// WithRowPerBuild exports one record for each build of each release, rather than one record per
+// release. Releases without builds are exported as a single record with empty build columns.
+func WithRowPerBuild() Opt {
+	return func(opts *opts) error {
+		opts.rowPerBuild = true
+		return nil
+	}
+}
+
+// WithFlatten causes WriteJSONLines to write flat objects containing the selected columns, with
+// builds and signature URLs rendered as strings, instead of the nested structure used by the
+// Releases API. CSV output is always flat.
+func WithFlatten() Opt {
+	return func(opts *opts) error {
+		opts.flatten = true
+		return nil
+	}
+}
+
+// records calls fn with each release yielded by items, together with the build it describes if
+// rows are exported per build. It returns the number of records for which fn was called. If items
+// yields an error, or fn returns an error, iteration stops and that error is returned.
+func (o opts) records(items iter.Seq2[releases.ReleaseInfo, error], fn func(releases.ReleaseInfo, *releases.BuildInfo) error) (int, error) {
+	count := 0
+	for release, err := range items {
+		if err != nil {
+			return count, err
+		}
+
+		if !o.rowPerBuild || len(release.Builds) == 0 {
+			if err := fn(release, nil); err != nil {
+				return count, err
+			}
+			count++
+			continue
+		}
+
+		for i := range release.Builds {
+			if err := fn(release, &release.Builds[i]); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (o opts) row(release releases.ReleaseInfo, build *releases.BuildInfo) []string {
+	row := make([]string, len(o.columns))
+	for i, column := range o.columns {
+		row[i] = value(column, release, build)
+	}
+	return row
+}
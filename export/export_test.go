@@ -0,0 +1,183 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"iter"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+	"github.com/jen20/go-hashicorp-releases-client/export"
+)
+
+var testReleases = []releases.ReleaseInfo{
+	{
+		Name:         "terraform",
+		Version:      "1.6.0",
+		LicenseClass: "oss",
+		Status:       releases.ReleaseStatus{State: releases.ReleaseStateSupported},
+		Builds: []releases.BuildInfo{
+			{OS: "linux", Arch: "amd64", URL: "https://releases.hashicorp.com/terraform/1.6.0/terraform_1.6.0_linux_amd64.zip"},
+			{OS: "darwin", Arch: "arm64", URL: "https://releases.hashicorp.com/terraform/1.6.0/terraform_1.6.0_darwin_arm64.zip"},
+		},
+		TimestampCreated: time.Date(2023, time.October, 4, 18, 0, 0, 0, time.UTC),
+		URLSHASUMsSignatures: []string{
+			"https://releases.hashicorp.com/terraform/1.6.0/terraform_1.6.0_SHA256SUMS.sig",
+			"https://releases.hashicorp.com/terraform/1.6.0/terraform_1.6.0_SHA256SUMS.72D7468F.sig",
+		},
+	},
+	{
+		Name:             "terraform",
+		Version:          "1.5.7",
+		LicenseClass:     "oss",
+		Status:           releases.ReleaseStatus{State: releases.ReleaseStateWithdrawn, Message: "Regression, use 1.6.0"},
+		TimestampCreated: time.Date(2023, time.September, 7, 17, 30, 0, 0, time.UTC),
+	},
+}
+
+func seqOf(items []releases.ReleaseInfo, tail error) iter.Seq2[releases.ReleaseInfo, error] {
+	return func(yield func(releases.ReleaseInfo, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if tail != nil {
+			yield(releases.ReleaseInfo{}, tail)
+		}
+	}
+}
+
+func readCSV(t *testing.T, data []byte) [][]string {
+	t.Helper()
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	return records
+}
+
+func TestWriteCSV(t *testing.T) {
+	t.Run("Default Columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		count, err := export.WriteCSV(&buf, seqOf(testReleases, nil))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != 2 {
+			t.Fatalf("expected 2 rows, got %d", count)
+		}
+
+		records := readCSV(t, buf.Bytes())
+		if len(records) != 3 || len(records[0]) != len(export.DefaultColumns) {
+			t.Fatalf("unexpected records: %v", records)
+		}
+		if !reflect.DeepEqual(records[2][:5], []string{"terraform", "1.5.7", "oss", "false", "withdrawn"}) {
+			t.Fatalf("unexpected row: %v", records[2])
+		}
+	})
+
+	t.Run("Selected Columns Per Build", func(t *testing.T) {
+		var buf bytes.Buffer
+		count, err := export.WriteCSV(&buf, seqOf(testReleases, nil),
+			export.WithRowPerBuild(),
+			export.WithColumns(export.ColumnVersion, export.ColumnBuildOS, export.ColumnBuildArch, export.ColumnURLSHASUMsSignatures))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != 3 {
+			t.Fatalf("expected 3 rows, got %d", count)
+		}
+
+		signatures := strings.Join(testReleases[0].URLSHASUMsSignatures, " ")
+		expected := [][]string{
+			{"version", "build_os", "build_arch", "url_shasums_signatures"},
+			{"1.6.0", "linux", "amd64", signatures},
+			{"1.6.0", "darwin", "arm64", signatures},
+			{"1.5.7", "", "", ""},
+		}
+		if records := readCSV(t, buf.Bytes()); !reflect.DeepEqual(expected, records) {
+			t.Fatalf("expected %v, got %v", expected, records)
+		}
+	})
+
+	t.Run("Iterator Error", func(t *testing.T) {
+		testErr := errors.New("page 2 failed")
+
+		var buf bytes.Buffer
+		count, err := export.WriteCSV(&buf, seqOf(testReleases[:1], testErr))
+		if !errors.Is(err, testErr) {
+			t.Fatalf("expected iterator error, got %v", err)
+		}
+		if count != 1 {
+			t.Fatalf("expected 1 row, got %d", count)
+		}
+		if records := readCSV(t, buf.Bytes()); len(records) != 2 {
+			t.Fatalf("rows before the error must be flushed, got %v", records)
+		}
+	})
+
+	t.Run("Unknown Column", func(t *testing.T) {
+		_, err := export.WriteCSV(&bytes.Buffer{}, seqOf(nil, nil), export.WithColumns("bogus"))
+		if !errors.Is(err, export.ErrUnknownColumn) {
+			t.Fatalf("expected ErrUnknownColumn, got %v", err)
+		}
+	})
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	t.Run("Nested", func(t *testing.T) {
+		var buf bytes.Buffer
+		count, err := export.WriteJSONLines(&buf, seqOf(testReleases, nil))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != 2 {
+			t.Fatalf("expected 2 lines, got %d", count)
+		}
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		var decoded releases.ReleaseInfo
+		if err := json.Unmarshal([]byte(lines[0]), &decoded); err != nil {
+			t.Fatalf("line is not valid JSON: %v", err)
+		}
+		if !reflect.DeepEqual(testReleases[0], decoded) {
+			t.Fatalf("expected %v, got %v", testReleases[0], decoded)
+		}
+	})
+
+	t.Run("Flattened Per Build", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := export.WriteJSONLines(&buf, seqOf(testReleases[:1], nil),
+			export.WithFlatten(),
+			export.WithRowPerBuild(),
+			export.WithColumns(export.ColumnVersion, export.ColumnBuilds, export.ColumnBuildURL))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := `{"version":"1.6.0","builds":"linux/amd64 darwin/arm64","build_url":"https://releases.hashicorp.com/terraform/1.6.0/terraform_1.6.0_linux_amd64.zip"}`
+		if lines := strings.Split(buf.String(), "\n"); lines[0] != expected {
+			t.Fatalf("expected %s, got %s", expected, lines[0])
+		}
+	})
+
+	t.Run("Iterator Error", func(t *testing.T) {
+		testErr := errors.New("page 2 failed")
+
+		var buf bytes.Buffer
+		count, err := export.WriteJSONLines(&buf, seqOf(testReleases, testErr))
+		if !errors.Is(err, testErr) {
+			t.Fatalf("expected iterator error, got %v", err)
+		}
+		if count != 2 || strings.Count(buf.String(), "\n") != 2 {
+			t.Fatalf("lines before the error must be flushed, got %d: %q", count, buf.String())
+		}
+	})
+}
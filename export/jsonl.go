@@ -0,0 +1,71 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"iter"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+// WriteJSONLines writes a JSON object on its own line for each release yielded by items to w, and
+// returns the number of lines written.
+//
+// By default each object has the structure used by the Releases API, and WithColumns has no
+// effect; if WithRowPerBuild is supplied, each object lists only the build it describes. If
+// WithFlatten is supplied, each object instead contains the selected columns as string values.
+//
+// If items yields an error, the lines written so far are flushed, and the error is returned along
+// with their count.
+func WriteJSONLines(w io.Writer, items iter.Seq2[releases.ReleaseInfo, error], options ...Opt) (int, error) {
+	effectiveOpts, err := newOpts(options...)
+	if err != nil {
+		return 0, err
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+
+	count, err := effectiveOpts.records(items, func(release releases.ReleaseInfo, build *releases.BuildInfo) error {
+		if effectiveOpts.flatten {
+			return writeFlatObject(bw, effectiveOpts.columns, effectiveOpts.row(release, build))
+		}
+
+		if build != nil {
+			release.Builds = []releases.BuildInfo{*build}
+		}
+		return enc.Encode(release)
+	})
+
+	if flushErr := bw.Flush(); flushErr != nil && err == nil {
+		err = flushErr
+	}
+	return count, err
+}
+
+// writeFlatObject writes a JSON object with a string member for each column, preserving the order
+// of columns, followed by a newline.
+func writeFlatObject(w *bufio.Writer, columns []Column, values []string) error {
+	_ = w.WriteByte('{')
+	for i, column := range columns {
+		if i > 0 {
+			_ = w.WriteByte(',')
+		}
+
+		key, err := json.Marshal(string(column))
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+
+		_, _ = w.Write(key)
+		_ = w.WriteByte(':')
+		_, _ = w.Write(value)
+	}
+	_, err := w.WriteString("}\n")
+	return err
+}
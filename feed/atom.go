@@ -0,0 +1,113 @@
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"iter"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+// AtomContentType is the media type of documents produced by WriteAtom.
+const AtomContentType = "application/atom+xml; charset=utf-8"
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomPerson  `xml:"author"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Published  string         `xml:"published,omitempty"`
+	Updated    string         `xml:"updated"`
+	Links      []atomLink     `xml:"link"`
+	Categories []atomCategory `xml:"category,omitempty"`
+	Summary    string         `xml:"summary"`
+}
+
+// WriteAtom renders the releases yielded by items as an Atom 1.0 feed, and writes it to w. If items
+// yields an error, nothing is written and the error is returned. The feed is updated at the latest
+// update of any entry, or at the time it is written if there are no entries.
+func WriteAtom(w io.Writer, meta Metadata, items iter.Seq2[releases.ReleaseInfo, error]) error {
+	entries, updated, err := collectEntries(items)
+	if err != nil {
+		return err
+	}
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+
+	feed := atomFeed{
+		ID:      meta.ID,
+		Title:   meta.Title,
+		Updated: formatAtomTime(updated),
+		Author:  atomPerson{Name: "HashiCorp"},
+	}
+	if meta.ID != "" {
+		feed.Links = append(feed.Links, atomLink{Rel: "self", Href: meta.ID})
+	}
+	if meta.Link != "" {
+		feed.Links = append(feed.Links, atomLink{Rel: "alternate", Href: meta.Link})
+	}
+
+	for _, e := range entries {
+		ae := atomEntry{
+			ID:      e.id,
+			Title:   e.title,
+			Updated: formatAtomTime(e.updated),
+			Links:   []atomLink{{Rel: "alternate", Href: e.link}},
+			Summary: e.summary,
+		}
+		if !e.published.IsZero() {
+			ae.Published = formatAtomTime(e.published)
+		}
+		if e.changelog != "" && e.changelog != e.link {
+			ae.Links = append(ae.Links, atomLink{Rel: "related", Href: e.changelog})
+		}
+		if e.state != "" {
+			ae.Categories = []atomCategory{{Term: string(e.state)}}
+		}
+		feed.Entries = append(feed.Entries, ae)
+	}
+
+	return writeXML(w, feed)
+}
+
+func formatAtomTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,132 @@
+// Package feed renders release metadata obtained from the HashiCorp Releases API as Atom 1.0 or
+// RSS 2.0 feeds, and provides an http.Handler which serves a feed for each product.
+package feed
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+// Metadata describes a feed as a whole.
+type Metadata struct {
+	// Title is the human-readable title of the feed.
+	Title string
+
+	// ID is a permanent, universally unique identifier for the feed, typically the URL at which it
+	// is served.
+	ID string
+
+	// Link is a URL for a web page related to the feed, such as the website of the product.
+	Link string
+
+	// Description is a human-readable description of the feed. If empty, Title is used.
+	Description string
+}
+
+// Items returns an iter.Seq2 over the supplied slice of releases, suitable for passing to WriteAtom
+// or WriteRSS.
+func Items(items []releases.ReleaseInfo) iter.Seq2[releases.ReleaseInfo, error] {
+	return func(yield func(releases.ReleaseInfo, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+// entry holds the format-independent content of a single feed entry.
+type entry struct {
+	id        string
+	title     string
+	link      string
+	changelog string
+	published time.Time
+	updated   time.Time
+	summary   string
+	state     releases.ReleaseState
+}
+
+func collectEntries(items iter.Seq2[releases.ReleaseInfo, error]) ([]entry, time.Time, error) {
+	var entries []entry
+	var latest time.Time
+
+	for item, err := range items {
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+
+		e := newEntry(item)
+		if e.updated.After(latest) {
+			latest = e.updated
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, latest, nil
+}
+
+func newEntry(release releases.ReleaseInfo) entry {
+	id := fmt.Sprintf("https://releases.hashicorp.com/%s/%s/", release.Name, release.Version)
+
+	title := fmt.Sprintf("%s %s", release.Name, release.Version)
+	if release.IsPrerelease {
+		title += " (prerelease)"
+	}
+	if release.Status.State == releases.ReleaseStateWithdrawn {
+		title += " (withdrawn)"
+	}
+
+	link := release.URLReleaseNotes
+	if link == "" {
+		link = release.URLChangelog
+	}
+	if link == "" {
+		link = id
+	}
+
+	updated := release.TimestampUpdated
+	if updated.IsZero() {
+		updated = release.TimestampCreated
+	}
+
+	return entry{
+		id:        id,
+		title:     title,
+		link:      link,
+		changelog: release.URLChangelog,
+		published: release.TimestampCreated,
+		updated:   updated,
+		summary:   summarize(release),
+		state:     release.Status.State,
+	}
+}
+
+func summarize(release releases.ReleaseInfo) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s %s was released on %s.", release.Name, release.Version,
+		release.TimestampCreated.UTC().Format(time.DateOnly))
+
+	switch release.Status.State {
+	case releases.ReleaseStateWithdrawn:
+		sb.WriteString(" This release has been withdrawn")
+		if release.Status.Message != "" {
+			fmt.Fprintf(&sb, ": %s", release.Status.Message)
+		} else {
+			sb.WriteString(".")
+		}
+	case releases.ReleaseStateUnsupported:
+		sb.WriteString(" This release is no longer supported.")
+	}
+
+	if release.URLChangelog != "" {
+		fmt.Fprintf(&sb, " Changelog: %s", release.URLChangelog)
+	}
+
+	return sb.String()
+}
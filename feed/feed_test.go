@@ -0,0 +1,163 @@
+package feed_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+	"github.com/jen20/go-hashicorp-releases-client/feed"
+)
+
+var testReleases = []releases.ReleaseInfo{
+	{
+		Name:             "vault",
+		Version:          "1.15.1",
+		Status:           releases.ReleaseStatus{State: releases.ReleaseStateWithdrawn, Message: "Critical regression in raft storage"},
+		TimestampCreated: time.Date(2023, time.October, 20, 10, 0, 0, 0, time.UTC),
+		TimestampUpdated: time.Date(2023, time.October, 25, 9, 30, 0, 0, time.UTC),
+		URLChangelog:     "https://github.com/hashicorp/vault/blob/main/CHANGELOG.md",
+		URLReleaseNotes:  "https://developer.hashicorp.com/vault/docs/release-notes/1.15.0",
+	},
+	{
+		Name:             "vault",
+		Version:          "1.15.0",
+		Status:           releases.ReleaseStatus{State: releases.ReleaseStateSupported},
+		TimestampCreated: time.Date(2023, time.September, 27, 15, 0, 0, 0, time.UTC),
+		TimestampUpdated: time.Date(2023, time.September, 27, 15, 0, 0, 0, time.UTC),
+		URLChangelog:     "https://github.com/hashicorp/vault/blob/main/CHANGELOG.md",
+	},
+}
+
+type testAtomFeed struct {
+	ID      string `xml:"id"`
+	Updated string `xml:"updated"`
+	Entries []struct {
+		ID        string `xml:"id"`
+		Title     string `xml:"title"`
+		Published string `xml:"published"`
+		Updated   string `xml:"updated"`
+		Summary   string `xml:"summary"`
+		Links     []struct {
+			Rel  string `xml:"rel,attr"`
+			Href string `xml:"href,attr"`
+		} `xml:"link"`
+		Category struct {
+			Term string `xml:"term,attr"`
+		} `xml:"category"`
+	} `xml:"entry"`
+}
+
+func TestWriteAtom(t *testing.T) {
+	var buf bytes.Buffer
+	meta := feed.Metadata{Title: "Vault releases", ID: "https://example.com/feeds/vault.atom"}
+	if err := feed.WriteAtom(&buf, meta, feed.Items(testReleases)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var parsed testAtomFeed
+	if err := xml.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+
+	if parsed.Updated != "2023-10-25T09:30:00Z" {
+		t.Fatalf("feed updated must be latest entry update, got %q", parsed.Updated)
+	}
+	if len(parsed.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(parsed.Entries))
+	}
+
+	withdrawn := parsed.Entries[0]
+	if withdrawn.Title != "vault 1.15.1 (withdrawn)" {
+		t.Fatalf("unexpected title %q", withdrawn.Title)
+	}
+	if withdrawn.Published != "2023-10-20T10:00:00Z" || withdrawn.Updated != "2023-10-25T09:30:00Z" {
+		t.Fatalf("unexpected dates: published %q, updated %q", withdrawn.Published, withdrawn.Updated)
+	}
+	if !strings.Contains(withdrawn.Summary, "withdrawn: Critical regression in raft storage") {
+		t.Fatalf("summary must note withdrawal, got %q", withdrawn.Summary)
+	}
+	if withdrawn.Category.Term != "withdrawn" {
+		t.Fatalf("unexpected category %q", withdrawn.Category.Term)
+	}
+	if withdrawn.Links[0].Href != testReleases[0].URLReleaseNotes || withdrawn.Links[1].Href != testReleases[0].URLChangelog {
+		t.Fatalf("unexpected links %v", withdrawn.Links)
+	}
+
+	if parsed.Entries[1].Links[0].Href != testReleases[1].URLChangelog {
+		t.Fatalf("entry without release notes must link to changelog, got %v", parsed.Entries[1].Links)
+	}
+}
+
+func TestWriteAtom_Empty(t *testing.T) {
+	before := time.Now().UTC().Truncate(time.Second)
+
+	var buf bytes.Buffer
+	if err := feed.WriteAtom(&buf, feed.Metadata{}, feed.Items(nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var parsed testAtomFeed
+	if err := xml.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+
+	updated, err := time.Parse(time.RFC3339, parsed.Updated)
+	if err != nil || updated.Before(before) || updated.After(time.Now()) {
+		t.Fatalf("empty feed updated must be the time of writing, got %q", parsed.Updated)
+	}
+}
+
+func TestWriteRSS(t *testing.T) {
+	var buf bytes.Buffer
+	meta := feed.Metadata{Title: "Vault releases", Link: "https://www.vaultproject.io/"}
+	if err := feed.WriteRSS(&buf, meta, feed.Items(testReleases)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var parsed struct {
+		Version string `xml:"version,attr"`
+		Channel struct {
+			Description string `xml:"description"`
+			Items       []struct {
+				Title   string `xml:"title"`
+				Link    string `xml:"link"`
+				PubDate string `xml:"pubDate"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+
+	if parsed.Version != "2.0" || parsed.Channel.Description != "Vault releases" {
+		t.Fatalf("unexpected channel: %+v", parsed)
+	}
+	if len(parsed.Channel.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(parsed.Channel.Items))
+	}
+	if parsed.Channel.Items[1].PubDate != "Wed, 27 Sep 2023 15:00:00 +0000" {
+		t.Fatalf("unexpected pubDate %q", parsed.Channel.Items[1].PubDate)
+	}
+}
+
+func TestWriteAtom_IteratorError(t *testing.T) {
+	testErr := errors.New("page 2 failed")
+	items := func(yield func(releases.ReleaseInfo, error) bool) {
+		if !yield(testReleases[0], nil) {
+			return
+		}
+		yield(releases.ReleaseInfo{}, testErr)
+	}
+
+	var buf bytes.Buffer
+	if err := feed.WriteAtom(&buf, feed.Metadata{}, items); !errors.Is(err, testErr) {
+		t.Fatalf("expected iterator error, got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("nothing must be written on error, got %q", buf.String())
+	}
+}
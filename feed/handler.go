@@ -0,0 +1,225 @@
+package feed
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+const defaultEntryLimit = 20
+
+var (
+	// ErrInvalidEntryLimit indicates that the limit supplied to WithEntryLimit is less than 1.
+	ErrInvalidEntryLimit = errors.New("invalid entry limit")
+
+	// ErrInvalidBaseURL indicates that the URL supplied to WithBaseURL is not an absolute HTTP or
+	// HTTPS URL.
+	ErrInvalidBaseURL = errors.New("invalid base URL")
+)
+
+// HandlerOpt is a functional option which can be used to configure the http.Handler returned by
+// the Handler function.
+type HandlerOpt func(*handlerOpts) error
+
+type handlerOpts struct {
+	entryLimit int
+	baseURL    *url.URL
+	logger     *slog.Logger
+}
+
+// WithEntryLimit sets the maximum number of releases included in each feed, newest first. If
+// unset, feeds contain the 20 most recent releases. Values less than 1 are rejected with an error
+// wrapping ErrInvalidEntryLimit.
+func WithEntryLimit(limit int) HandlerOpt {
+	return func(opts *handlerOpts) error {
+		if limit < 1 {
+			return fmt.Errorf("%w: must be at least 1, got %d", ErrInvalidEntryLimit, limit)
+		}
+		opts.entryLimit = limit
+		return nil
+	}
+}
+
+// WithBaseURL sets the public URL under which feeds are served, such as
+// "https://example.com/feeds/". The URL of each feed, used as its ID and self link, is formed by
+// appending the final element of the request path and any license_class query parameter. URLs
+// other than absolute HTTP or HTTPS URLs are rejected with an error wrapping ErrInvalidBaseURL.
+//
+// If unset, the URL of each feed is taken from the request, using the Host header supplied by the
+// client, and the https scheme only if the request was received over TLS. This is incorrect behind
+// a reverse proxy which terminates TLS or rewrites paths, so WithBaseURL should be used wherever
+// feeds are published.
+func WithBaseURL(baseURL string) HandlerOpt {
+	return func(opts *handlerOpts) error {
+		parsed, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidBaseURL, err)
+		}
+		if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("%w: %q is not an absolute HTTP or HTTPS URL", ErrInvalidBaseURL, baseURL)
+		}
+		opts.baseURL = parsed
+		return nil
+	}
+}
+
+// WithLogger sets the logger to which errors retrieving releases are written, since the response
+// reports only their status. If unset, or if logger is nil, slog.Default is used.
+func WithLogger(logger *slog.Logger) HandlerOpt {
+	return func(opts *handlerOpts) error {
+		opts.logger = logger
+		return nil
+	}
+}
+
+type handler struct {
+	client releases.ReleasesAPI
+	opts   handlerOpts
+}
+
+// Handler returns an http.Handler which serves a feed of the releases of each product, using client,
+// which is usually a *releases.Client, to retrieve them. The final element of the request path
+// selects the product and feed format: a request for "/feeds/terraform.atom" is served an Atom feed
+// of Terraform releases, and a request for "/feeds/terraform.rss" an RSS feed. The license class
+// may be selected using the "license_class" query parameter, which accepts the values "oss",
+// "enterprise" or "hcp".
+func Handler(client releases.ReleasesAPI, opts ...HandlerOpt) (http.Handler, error) {
+	effectiveOpts := handlerOpts{
+		entryLimit: defaultEntryLimit,
+	}
+	for _, opt := range opts {
+		if err := opt(&effectiveOpts); err != nil {
+			return nil, err
+		}
+	}
+	if effectiveOpts.logger == nil {
+		effectiveOpts.logger = slog.Default()
+	}
+
+	return &handler{
+		client: client,
+		opts:   effectiveOpts,
+	}, nil
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := path.Base(r.URL.Path)
+	ext := path.Ext(name)
+	product := strings.TrimSuffix(name, ext)
+
+	var write func(io.Writer, Metadata, iter.Seq2[releases.ReleaseInfo, error]) error
+	var contentType string
+	switch ext {
+	case ".atom":
+		write, contentType = WriteAtom, AtomContentType
+	case ".rss":
+		write, contentType = WriteRSS, RSSContentType
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	licenseClass, ok := parseLicenseClass(r.URL.Query().Get("license_class"))
+	if !ok {
+		http.Error(w, "invalid license_class", http.StatusBadRequest)
+		return
+	}
+
+	items, err := h.client.Releases(r.Context(), product, licenseClass)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	recent := make([]releases.ReleaseInfo, 0, h.opts.entryLimit)
+	for item, err := range items {
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		if recent = append(recent, item); len(recent) == h.opts.entryLimit {
+			break
+		}
+	}
+
+	meta := Metadata{
+		Title: fmt.Sprintf("%s releases", product),
+		ID:    h.feedURL(r, licenseClass),
+	}
+	if len(recent) > 0 {
+		meta.Link = recent[0].URLProjectWebsite
+	}
+
+	var buf bytes.Buffer
+	if err := write(&buf, meta, Items(recent)); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_, _ = buf.WriteTo(w)
+	}
+}
+
+func (h *handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, releases.ErrNotFound), errors.Is(err, releases.ErrInvalidProduct):
+		http.NotFound(w, r)
+	default:
+		h.opts.logger.ErrorContext(r.Context(), "feed: retrieving releases", slog.String("path", r.URL.Path), slog.Any("error", err))
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+	}
+}
+
+func parseLicenseClass(value string) (*releases.LicenseClass, bool) {
+	if value == "" {
+		return nil, true
+	}
+
+	for _, licenseClass := range []*releases.LicenseClass{
+		releases.LicenseClassOSS,
+		releases.LicenseClassEnterprise,
+		releases.LicenseClassHCP,
+	} {
+		if string(*licenseClass) == value {
+			return licenseClass, true
+		}
+	}
+	return nil, false
+}
+
+// feedURL returns the URL of the feed requested by r, relative to the URL supplied using
+// WithBaseURL if any, or otherwise to the scheme and Host header of the request.
+func (h *handler) feedURL(r *http.Request, licenseClass *releases.LicenseClass) string {
+	if h.opts.baseURL == nil {
+		scheme := "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+		return fmt.Sprintf("%s://%s%s", scheme, r.Host, r.URL.RequestURI())
+	}
+
+	feedURL := h.opts.baseURL.JoinPath(path.Base(r.URL.Path))
+	feedURL.RawQuery = ""
+	if licenseClass != nil {
+		feedURL.RawQuery = url.Values{"license_class": {string(*licenseClass)}}.Encode()
+	}
+	return feedURL.String()
+}
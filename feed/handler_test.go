@@ -0,0 +1,207 @@
+package feed_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+	"github.com/jen20/go-hashicorp-releases-client/feed"
+)
+
+func TestHandler(t *testing.T) {
+	api := http.NewServeMux()
+	api.HandleFunc("GET /v1/releases/vault", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("license_class") != "enterprise" && r.URL.Query().Has("license_class") {
+			t.Errorf("unexpected license class %q", r.URL.Query().Get("license_class"))
+		}
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(testReleases); err != nil {
+			t.Errorf("failed to encode releases: %v", err)
+		}
+	})
+	apiServer := httptest.NewServer(api)
+	defer apiServer.Close()
+
+	client, err := releases.New(releases.WithBaseURL(apiServer.URL))
+	if err != nil {
+		t.Fatalf("unexpected error constructing client: %v", err)
+	}
+
+	handler, err := feed.Handler(client, feed.WithEntryLimit(1))
+	if err != nil {
+		t.Fatalf("unexpected error constructing handler: %v", err)
+	}
+
+	testCases := []struct {
+		path        string
+		status      int
+		contentType string
+	}{
+		{path: "/feeds/vault.atom", status: http.StatusOK, contentType: feed.AtomContentType},
+		{path: "/feeds/vault.rss?license_class=enterprise", status: http.StatusOK, contentType: feed.RSSContentType},
+		{path: "/feeds/vault.json", status: http.StatusNotFound},
+		{path: "/feeds/vault.atom?license_class=bogus", status: http.StatusBadRequest},
+		{path: "/feeds/unknown.atom", status: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			if rec.Code != tc.status {
+				t.Fatalf("expected status %d, got %d: %s", tc.status, rec.Code, rec.Body.String())
+			}
+			if tc.status != http.StatusOK {
+				return
+			}
+			if contentType := rec.Header().Get("Content-Type"); contentType != tc.contentType {
+				t.Fatalf("expected content type %q, got %q", tc.contentType, contentType)
+			}
+
+			var parsed struct {
+				Entries []struct{} `xml:"entry"`
+				Items   []struct{} `xml:"channel>item"`
+			}
+			if err := xml.Unmarshal(rec.Body.Bytes(), &parsed); err != nil {
+				t.Fatalf("response is not valid XML: %v", err)
+			}
+			if len(parsed.Entries)+len(parsed.Items) != 1 {
+				t.Fatalf("entry limit must be applied, got %d entries", len(parsed.Entries)+len(parsed.Items))
+			}
+		})
+	}
+}
+
+func TestHandler_Snapshot(t *testing.T) {
+	handler, err := feed.Handler(releases.NewSnapshot(testReleases...))
+	if err != nil {
+		t.Fatalf("unexpected error constructing handler: %v", err)
+	}
+
+	for path, status := range map[string]int{
+		"/feeds/vault.atom":   http.StatusOK,
+		"/feeds/unknown.atom": http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if rec.Code != status {
+			t.Fatalf("expected status %d for %s, got %d: %s", status, path, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestHandler_UpstreamError(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer apiServer.Close()
+
+	client, err := releases.New(releases.WithBaseURL(apiServer.URL))
+	if err != nil {
+		t.Fatalf("unexpected error constructing client: %v", err)
+	}
+
+	var logs bytes.Buffer
+	handler, err := feed.Handler(client, feed.WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+	if err != nil {
+		t.Fatalf("unexpected error constructing handler: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/feeds/vault.atom", nil))
+
+	if rec.Code != http.StatusBadGateway {
+		t.Fatalf("expected status %d, got %d", http.StatusBadGateway, rec.Code)
+	}
+	if body := strings.TrimSpace(rec.Body.String()); body != http.StatusText(http.StatusBadGateway) {
+		t.Fatalf("upstream error detail must not be sent, got %q", body)
+	}
+	if !strings.Contains(logs.String(), "403") {
+		t.Fatalf("upstream error detail must be logged, got %q", logs.String())
+	}
+}
+
+func TestHandler_FeedURL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     []feed.HandlerOpt
+		target   string
+		expected string
+	}{
+		{
+			name:     "Base URL",
+			opts:     []feed.HandlerOpt{feed.WithBaseURL("https://example.com/feeds/")},
+			target:   "http://internal:8080/vault.atom?license_class=enterprise&utm_source=x",
+			expected: "https://example.com/feeds/vault.atom?license_class=enterprise",
+		},
+		{
+			name:     "Request URL",
+			target:   "http://internal:8080/feeds/vault.atom",
+			expected: "http://internal:8080/feeds/vault.atom",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, err := feed.Handler(releases.NewSnapshot(testReleases...), tc.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error constructing handler: %v", err)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.target, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+			}
+
+			var parsed struct {
+				ID    string `xml:"id"`
+				Links []struct {
+					Rel  string `xml:"rel,attr"`
+					Href string `xml:"href,attr"`
+				} `xml:"link"`
+			}
+			if err := xml.Unmarshal(rec.Body.Bytes(), &parsed); err != nil {
+				t.Fatalf("response is not valid XML: %v", err)
+			}
+			if parsed.ID != tc.expected {
+				t.Fatalf("expected ID %q, got %q", tc.expected, parsed.ID)
+			}
+			for _, link := range parsed.Links {
+				if link.Rel == "self" && link.Href != tc.expected {
+					t.Fatalf("expected self link %q, got %q", tc.expected, link.Href)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_InvalidOptions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opt      feed.HandlerOpt
+		expected error
+	}{
+		{name: "Entry Limit", opt: feed.WithEntryLimit(0), expected: feed.ErrInvalidEntryLimit},
+		{name: "Relative Base URL", opt: feed.WithBaseURL("/feeds/"), expected: feed.ErrInvalidBaseURL},
+		{name: "Base URL Scheme", opt: feed.WithBaseURL("ftp://example.com/feeds/"), expected: feed.ErrInvalidBaseURL},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := feed.Handler(releases.NewSnapshot(testReleases...), tc.opt)
+			if !errors.Is(err, tc.expected) {
+				t.Fatalf("expected error wrapping %v, got: %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"iter"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+// RSSContentType is the media type of documents produced by WriteRSS.
+const RSSContentType = "application/rss+xml; charset=utf-8"
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate,omitempty"`
+	Category    string  `xml:"category,omitempty"`
+	Description string  `xml:"description"`
+}
+
+// WriteRSS renders the releases yielded by items as an RSS 2.0 feed, and writes it to w. If items
+// yields an error, nothing is written and the error is returned.
+func WriteRSS(w io.Writer, meta Metadata, items iter.Seq2[releases.ReleaseInfo, error]) error {
+	entries, updated, err := collectEntries(items)
+	if err != nil {
+		return err
+	}
+
+	description := meta.Description
+	if description == "" {
+		description = meta.Title
+	}
+
+	doc := rssDocument{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       meta.Title,
+			Link:        meta.Link,
+			Description: description,
+		},
+	}
+	if !updated.IsZero() {
+		doc.Channel.LastBuildDate = formatRSSTime(updated)
+	}
+
+	for _, e := range entries {
+		item := rssItem{
+			Title:       e.title,
+			Link:        e.link,
+			GUID:        rssGUID{Value: e.id},
+			Category:    string(e.state),
+			Description: e.summary,
+		}
+		if !e.published.IsZero() {
+			item.PubDate = formatRSSTime(e.published)
+		}
+		doc.Channel.Items = append(doc.Channel.Items, item)
+	}
+
+	return writeXML(w, doc)
+}
+
+func formatRSSTime(t time.Time) string {
+	return t.UTC().Format(time.RFC1123Z)
+}
@@ -0,0 +1,159 @@
+package releases
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// RegistryDockerHub is the canonical name of the Docker Hub registry used in an ImageRef.
+	RegistryDockerHub = "docker.io"
+
+	// RegistryECRPublic is the name of the public AWS Elastic Container Registry used in an ImageRef.
+	RegistryECRPublic = "public.ecr.aws"
+
+	// defaultImageTag is the tag of image references which specify neither a tag nor a digest.
+	defaultImageTag = "latest"
+)
+
+// ImageRef is a reference to a container image in a registry.
+type ImageRef struct {
+	// Registry is the host name of the registry, for example "docker.io" or "public.ecr.aws".
+	Registry string
+
+	// Repository is the path of the repository within the registry, for example "hashicorp/vault".
+	Repository string
+
+	// Tag is the tag of the image within the repository, for example "1.15.0". It may be empty if
+	// Digest is set.
+	Tag string
+
+	// Digest is the content digest of the image manifest, for example "sha256:4e2d...". It is
+	// typically empty unless the reference has been resolved or was parsed from a pinned reference.
+	Digest string
+}
+
+// String returns the reference in the form "registry/repository:tag@digest", omitting the tag or
+// digest if they are empty.
+func (r ImageRef) String() string {
+	var sb strings.Builder
+	sb.WriteString(r.Registry)
+	sb.WriteByte('/')
+	sb.WriteString(r.Repository)
+	if r.Tag != "" {
+		sb.WriteByte(':')
+		sb.WriteString(r.Tag)
+	}
+	if r.Digest != "" {
+		sb.WriteByte('@')
+		sb.WriteString(r.Digest)
+	}
+	return sb.String()
+}
+
+// ParseImageRef parses an image reference as accepted by "docker pull", such as
+// "hashicorp/vault:1.15.0" or "public.ecr.aws/hashicorp/vault@sha256:...". References without a
+// registry refer to Docker Hub, and single-component Docker Hub repositories are qualified with
+// "library/". References with neither a tag nor a digest refer to the "latest" tag.
+func ParseImageRef(s string) (ImageRef, error) {
+	var ref ImageRef
+
+	rest := s
+	if idx := strings.IndexByte(rest, '@'); idx >= 0 {
+		rest, ref.Digest = rest[:idx], rest[idx+1:]
+		if !strings.Contains(ref.Digest, ":") {
+			return ImageRef{}, fmt.Errorf("%w: %q has an invalid digest", ErrInvalidImageRef, s)
+		}
+	}
+
+	if idx := strings.LastIndexByte(rest, ':'); idx > strings.LastIndexByte(rest, '/') {
+		rest, ref.Tag = rest[:idx], rest[idx+1:]
+		if ref.Tag == "" {
+			return ImageRef{}, fmt.Errorf("%w: %q has an empty tag", ErrInvalidImageRef, s)
+		}
+	} else if ref.Digest == "" {
+		ref.Tag = defaultImageTag
+	}
+
+	first, remainder, found := strings.Cut(rest, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.Registry, ref.Repository = first, remainder
+	} else {
+		ref.Registry, ref.Repository = RegistryDockerHub, rest
+	}
+
+	if ref.Registry == "index.docker.io" || ref.Registry == "registry-1.docker.io" {
+		ref.Registry = RegistryDockerHub
+	}
+	if ref.Registry == RegistryDockerHub && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+
+	if ref.Repository == "" || strings.HasSuffix(ref.Repository, "/") {
+		return ImageRef{}, fmt.Errorf("%w: %q", ErrInvalidImageRef, s)
+	}
+	return ref, nil
+}
+
+// DockerHubImage returns a reference to the Docker Hub image for this release, derived from
+// URLDockerRegistryDockerhub. The tag is taken from DockerNameTag if it is set, and is otherwise
+// the release version. If the release has no Docker Hub image, ErrNoImage is returned.
+func (r ReleaseInfo) DockerHubImage() (ImageRef, error) {
+	repository, err := imageRepositoryFromURL(r.URLDockerRegistryDockerhub, "hub.docker.com", "/r/")
+	if err != nil {
+		return ImageRef{}, err
+	}
+	if repository == "" {
+		return ImageRef{}, fmt.Errorf("%w: %s %s has no Docker Hub image", ErrNoImage, r.Name, r.Version)
+	}
+	if !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+
+	return ImageRef{Registry: RegistryDockerHub, Repository: repository, Tag: r.imageTag()}, nil
+}
+
+// ECRImage returns a reference to the public AWS Elastic Container Registry image for this
+// release, derived from URLDockerRegistryECR. The tag is taken from DockerNameTag if it is set,
+// and is otherwise the release version. If the release has no ECR image, ErrNoImage is returned.
+func (r ReleaseInfo) ECRImage() (ImageRef, error) {
+	repository, err := imageRepositoryFromURL(r.URLDockerRegistryECR, "gallery.ecr.aws", "/")
+	if err != nil {
+		return ImageRef{}, err
+	}
+	if repository == "" {
+		return ImageRef{}, fmt.Errorf("%w: %s %s has no ECR image", ErrNoImage, r.Name, r.Version)
+	}
+
+	return ImageRef{Registry: RegistryECRPublic, Repository: repository, Tag: r.imageTag()}, nil
+}
+
+func (r ReleaseInfo) imageTag() string {
+	if _, tag, found := strings.Cut(r.DockerNameTag, ":"); found && tag != "" {
+		return tag
+	}
+	return r.Version
+}
+
+// imageRepositoryFromURL extracts the repository path from the URL of a registry web page, such as
+// https://hub.docker.com/r/hashicorp/vault. It returns an empty string if pageURL is empty.
+func imageRepositoryFromURL(pageURL string, host string, prefix string) (string, error) {
+	if pageURL == "" {
+		return "", nil
+	}
+
+	parsed, err := url.Parse(pageURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidImageRef, err)
+	}
+	if parsed.Host != host || !strings.HasPrefix(parsed.Path, prefix) {
+		return "", fmt.Errorf("%w: unrecognized registry URL %q", ErrInvalidImageRef, pageURL)
+	}
+
+	repository := strings.Trim(strings.TrimPrefix(parsed.Path, prefix), "/")
+	if repository == "" {
+		return "", fmt.Errorf("%w: unrecognized registry URL %q", ErrInvalidImageRef, pageURL)
+	}
+	return repository, nil
+}
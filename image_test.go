@@ -0,0 +1,59 @@
+package releases_test
+
+import (
+	"errors"
+	"testing"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+func TestParseImageRef(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected releases.ImageRef
+	}{
+		{"hashicorp/vault:1.15.0", releases.ImageRef{Registry: "docker.io", Repository: "hashicorp/vault", Tag: "1.15.0"}},
+		{"consul:1.16", releases.ImageRef{Registry: "docker.io", Repository: "library/consul", Tag: "1.16"}},
+		{"hashicorp/vault", releases.ImageRef{Registry: "docker.io", Repository: "hashicorp/vault", Tag: "latest"}},
+		{"localhost:5000/vault", releases.ImageRef{Registry: "localhost:5000", Repository: "vault", Tag: "latest"}},
+		{"public.ecr.aws/hashicorp/vault:1.15.0", releases.ImageRef{Registry: "public.ecr.aws", Repository: "hashicorp/vault", Tag: "1.15.0"}},
+		{"localhost:5000/vault@sha256:abcd", releases.ImageRef{Registry: "localhost:5000", Repository: "vault", Digest: "sha256:abcd"}},
+		{"index.docker.io/hashicorp/vault:1.15.0@sha256:abcd", releases.ImageRef{Registry: "docker.io", Repository: "hashicorp/vault", Tag: "1.15.0", Digest: "sha256:abcd"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := releases.ParseImageRef(tc.input)
+			requireNoError(t, err)
+			requireEqual(t, tc.expected, ref)
+		})
+	}
+
+	for _, input := range []string{"hashicorp/vault:", "docker.io/:1.0", "vault@abcd"} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := releases.ParseImageRef(input); !errors.Is(err, releases.ErrInvalidImageRef) {
+				t.Fatalf("expected ErrInvalidImageRef, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestReleaseInfo_Images(t *testing.T) {
+	dockerHub, err := waypoint_0_11_4.DockerHubImage()
+	requireNoError(t, err)
+	requireEqual(t, "docker.io/hashicorp/waypoint:0.11.4", dockerHub.String())
+
+	ecr, err := waypoint_0_11_4.ECRImage()
+	requireNoError(t, err)
+	requireEqual(t, "public.ecr.aws/hashicorp/waypoint:0.11.4", ecr.String())
+
+	tagged := waypoint_0_11_4
+	tagged.DockerNameTag = "hashicorp/waypoint:0.11.4-ubi"
+	dockerHub, err = tagged.DockerHubImage()
+	requireNoError(t, err)
+	requireEqual(t, "0.11.4-ubi", dockerHub.Tag)
+
+	if _, err := waypoint_0_1_0.DockerHubImage(); !errors.Is(err, releases.ErrNoImage) {
+		t.Fatalf("expected ErrNoImage, got: %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+package releases
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// jsonExtra holds the members of a JSON object which do not correspond to a field of the type into
+// which it was decoded. ReleaseInfo, BuildInfo and ReleaseStatus refer to it by pointer so that the
+// latter two remain comparable. It is not modified once created, so may be shared between copies.
+type jsonExtra struct {
+	values map[string]json.RawMessage
+}
+
+func newJSONExtra(values map[string]json.RawMessage) *jsonExtra {
+	if len(values) == 0 {
+		return nil
+	}
+	return &jsonExtra{values: values}
+}
+
+// members returns a copy of the members held by e, or nil if e is nil.
+func (e *jsonExtra) members() map[string]json.RawMessage {
+	if e == nil {
+		return nil
+	}
+	return maps.Clone(e.values)
+}
+
+// knownFieldNames caches the JSON member names corresponding to the fields of each struct type for
+// which unknown members are preserved.
+var knownFieldNames sync.Map
+
+func jsonFieldNames(t reflect.Type) map[string]struct{} {
+	if cached, ok := knownFieldNames.Load(t); ok {
+		return cached.(map[string]struct{})
+	}
+
+	names := make(map[string]struct{}, t.NumField())
+	for _, field := range reflect.VisibleFields(t) {
+		tag := field.Tag.Get("json")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = struct{}{}
+	}
+
+	knownFieldNames.Store(t, names)
+	return names
+}
+
+// isKnownMember reports whether a JSON member name corresponds to one of the known field names.
+// Like encoding/json, it prefers an exact match, but otherwise matches names case-insensitively.
+func isKnownMember(known map[string]struct{}, name string) bool {
+	if _, ok := known[name]; ok {
+		return true
+	}
+	for knownName := range known {
+		if strings.EqualFold(knownName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// unmarshalWithExtra decodes data into target, which must be a pointer to a struct type without
+// custom JSON methods, and returns any members of the JSON object which do not correspond to a
+// field of that type. If there are no such members, the returned map is nil.
+func unmarshalWithExtra[T any](data []byte, target *T) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, target); err != nil {
+		return nil, err
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	known := jsonFieldNames(reflect.TypeFor[T]())
+	var extra map[string]json.RawMessage
+	for name, value := range members {
+		if isKnownMember(known, name) {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[name] = value
+	}
+
+	return extra, nil
+}
+
+// marshalWithExtra encodes value, which must be a struct type without custom JSON methods, and
+// appends the members of extra which do not correspond to a field of that type, in sorted order.
+func marshalWithExtra[T any](value T, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	known := jsonFieldNames(reflect.TypeFor[T]())
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for _, name := range slices.Sorted(maps.Keys(extra)) {
+		if isKnownMember(known, name) {
+			continue
+		}
+
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+
+		raw := extra[name]
+		if len(raw) == 0 {
+			raw = json.RawMessage("null")
+		}
+
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(raw)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+type plainBuildInfo BuildInfo
+
+// UnmarshalJSON decodes a BuildInfo, retaining any unrecognized members, which are returned by
+// Extra.
+func (b *BuildInfo) UnmarshalJSON(data []byte) error {
+	var plain plainBuildInfo
+	extra, err := unmarshalWithExtra(data, &plain)
+	if err != nil {
+		return err
+	}
+
+	*b = BuildInfo(plain)
+	b.extra = newJSONExtra(extra)
+	return nil
+}
+
+// MarshalJSON encodes a BuildInfo, including any unrecognized members retained when it was
+// decoded.
+func (b BuildInfo) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra(plainBuildInfo(b), b.extra.members())
+}
+
+type plainReleaseStatus ReleaseStatus
+
+// UnmarshalJSON decodes a ReleaseStatus, retaining any unrecognized members, which are returned by
+// Extra.
+func (s *ReleaseStatus) UnmarshalJSON(data []byte) error {
+	var plain plainReleaseStatus
+	extra, err := unmarshalWithExtra(data, &plain)
+	if err != nil {
+		return err
+	}
+
+	*s = ReleaseStatus(plain)
+	s.extra = newJSONExtra(extra)
+	return nil
+}
+
+// MarshalJSON encodes a ReleaseStatus, including any unrecognized members retained when it was
+// decoded. TimestampUpdated is omitted if it is zero.
+func (s ReleaseStatus) MarshalJSON() ([]byte, error) {
+	value := struct {
+		plainReleaseStatus
+		TimestampUpdated *time.Time `json:"timestamp_updated,omitempty"`
+	}{
+		plainReleaseStatus: plainReleaseStatus(s),
+	}
+	if !s.TimestampUpdated.IsZero() {
+		value.TimestampUpdated = &s.TimestampUpdated
+	}
+
+	return marshalWithExtra(value, s.extra.members())
+}
+
+type plainReleaseInfo ReleaseInfo
+
+// UnmarshalJSON decodes a ReleaseInfo, retaining any unrecognized members, which are returned by
+// Extra.
+func (r *ReleaseInfo) UnmarshalJSON(data []byte) error {
+	var plain plainReleaseInfo
+	extra, err := unmarshalWithExtra(data, &plain)
+	if err != nil {
+		return err
+	}
+
+	*r = ReleaseInfo(plain)
+	r.extra = newJSONExtra(extra)
+	return nil
+}
+
+// MarshalJSON encodes a ReleaseInfo, including any unrecognized members retained when it was
+// decoded.
+func (r ReleaseInfo) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra(plainReleaseInfo(r), r.extra.members())
+}
@@ -0,0 +1,120 @@
+package releases_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+func TestReleaseInfo_UnknownFields(t *testing.T) {
+	input := `{
+		"name": "vault",
+		"version": "1.15.0",
+		"sbom_url": "https://releases.hashicorp.com/vault/1.15.0/vault_1.15.0.sbom.json",
+		"status": {"state": "supported", "reason": "general availability"},
+		"builds": [{"os": "linux", "arch": "amd64", "url": "https://example.com/vault.zip", "size": 123456}]
+	}`
+
+	var release releases.ReleaseInfo
+	requireNoError(t, json.Unmarshal([]byte(input), &release))
+
+	requireEqual(t, "1.15.0", release.Version)
+	requireEqual(t, map[string]json.RawMessage{
+		"sbom_url": json.RawMessage(`"https://releases.hashicorp.com/vault/1.15.0/vault_1.15.0.sbom.json"`),
+	}, release.Extra())
+	requireEqual(t, map[string]json.RawMessage{
+		"reason": json.RawMessage(`"general availability"`),
+	}, release.Status.Extra())
+	requireEqual(t, map[string]json.RawMessage{
+		"size": json.RawMessage(`123456`),
+	}, release.Builds[0].Extra())
+
+	encoded, err := json.Marshal(release)
+	requireNoError(t, err)
+
+	var roundTripped releases.ReleaseInfo
+	requireNoError(t, json.Unmarshal(encoded, &roundTripped))
+	requireEqual(t, release, roundTripped)
+
+	var members map[string]any
+	requireNoError(t, json.Unmarshal(encoded, &members))
+	requireEqual(t, "https://releases.hashicorp.com/vault/1.15.0/vault_1.15.0.sbom.json", members["sbom_url"])
+}
+
+func TestReleaseInfo_NoUnknownFields(t *testing.T) {
+	var status releases.ReleaseStatus
+	requireNoError(t, json.Unmarshal([]byte(`{"state": "withdrawn", "message": "Regression"}`), &status))
+
+	if extra := status.Extra(); extra != nil {
+		t.Fatalf("Extra must be nil when there are no unknown fields, got %v", extra)
+	}
+
+	encoded, err := json.Marshal(status)
+	requireNoError(t, err)
+	requireEqual(t, `{"message":"Regression","state":"withdrawn"}`, string(encoded))
+}
+
+func TestReleaseInfo_CaseInsensitiveFields(t *testing.T) {
+	var release releases.ReleaseInfo
+	requireNoError(t, json.Unmarshal([]byte(`{"Name": "vault", "VERSION": "1.15.0", "status": {"State": "supported"}}`), &release))
+
+	requireEqual(t, "vault", release.Name)
+	requireEqual(t, "1.15.0", release.Version)
+	requireEqual(t, releases.ReleaseStateSupported, release.Status.State)
+	if release.Extra() != nil || release.Status.Extra() != nil {
+		t.Fatalf("members matching fields case-insensitively must not be retained, got %v and %v", release.Extra(), release.Status.Extra())
+	}
+}
+
+func TestReleaseInfo_SetExtra(t *testing.T) {
+	extra := map[string]json.RawMessage{"sbom_url": json.RawMessage(`"https://example.com/sbom.json"`)}
+
+	release := releases.ReleaseInfo{Name: "vault", Version: "1.15.0", Builds: []releases.BuildInfo{{OS: "linux"}}}
+	release.SetExtra(extra)
+	release.Status.SetExtra(map[string]json.RawMessage{"reason": json.RawMessage(`"general availability"`)})
+	release.Builds[0].SetExtra(map[string]json.RawMessage{"size": json.RawMessage(`123456`)})
+
+	// The members are copied, so later changes to the supplied map have no effect.
+	extra["sbom_url"] = json.RawMessage(`null`)
+
+	encoded, err := json.Marshal(release)
+	requireNoError(t, err)
+
+	var roundTripped releases.ReleaseInfo
+	requireNoError(t, json.Unmarshal(encoded, &roundTripped))
+	requireEqual(t, map[string]json.RawMessage{
+		"sbom_url": json.RawMessage(`"https://example.com/sbom.json"`),
+	}, roundTripped.Extra())
+	requireEqual(t, map[string]json.RawMessage{
+		"reason": json.RawMessage(`"general availability"`),
+	}, roundTripped.Status.Extra())
+	requireEqual(t, map[string]json.RawMessage{
+		"size": json.RawMessage(`123456`),
+	}, roundTripped.Builds[0].Extra())
+
+	release.SetExtra(nil)
+	if extra := release.Extra(); extra != nil {
+		t.Fatalf("Extra must be nil once cleared, got %v", extra)
+	}
+}
+
+func TestReleaseStatus_Comparable(t *testing.T) {
+	var status releases.ReleaseStatus
+	requireNoError(t, json.Unmarshal([]byte(`{"state": "withdrawn", "message": "Regression"}`), &status))
+
+	if status != (releases.ReleaseStatus{State: releases.ReleaseStateWithdrawn, Message: "Regression"}) {
+		t.Fatalf("unexpected status %+v", status)
+	}
+}
+
+func TestReleaseStatus_TimestampUpdated(t *testing.T) {
+	input := `{"message":"Regression","state":"withdrawn","timestamp_updated":"2023-10-25T09:30:00Z"}`
+
+	var status releases.ReleaseStatus
+	requireNoError(t, json.Unmarshal([]byte(input), &status))
+
+	encoded, err := json.Marshal(status)
+	requireNoError(t, err)
+	requireEqual(t, input, string(encoded))
+}
@@ -0,0 +1,257 @@
+package releases
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+//go:embed keyring.json
+var defaultKeyRingJSON []byte
+
+// SigningKey describes a key which HashiCorp uses to sign the SHA256SUMS files of releases.
+type SigningKey struct {
+	// Fingerprint is the OpenPGP fingerprint of the key, as 40 uppercase hexadecimal digits.
+	Fingerprint string
+
+	// PublicKey is the ASCII-armored public key, for use by the caller's OpenPGP implementation,
+	// since this library does not verify signatures itself. It may be empty if the key is known only
+	// by fingerprint. HashiCorp publishes its current key at
+	// https://www.hashicorp.com/.well-known/pgp-key.txt.
+	PublicKey string
+
+	// NotBefore is the earliest creation time of a release which the key is trusted to sign. It
+	// is zero if there is no lower bound.
+	NotBefore time.Time
+
+	// NotAfter is the time from which releases are no longer trusted to be signed by the key,
+	// usually because it has been rotated. It is zero if there is no upper bound.
+	NotAfter time.Time
+}
+
+// ID returns the short key ID, which is the last 8 hexadecimal digits of the fingerprint. It
+// appears in the filenames of signatures, for example "vault_1.15.0_SHA256SUMS.72D7468F.sig".
+func (k SigningKey) ID() string {
+	return k.Fingerprint[len(k.Fingerprint)-8:]
+}
+
+// Covers returns true if the key is trusted to sign releases created at t.
+func (k SigningKey) Covers(t time.Time) bool {
+	if !k.NotBefore.IsZero() && t.Before(k.NotBefore) {
+		return false
+	}
+	if !k.NotAfter.IsZero() && !t.Before(k.NotAfter) {
+		return false
+	}
+	return true
+}
+
+// KeyRing is an ordered set of trusted signing keys, used to select which of the signatures of a
+// release to verify. DefaultKeyRing returns the keys HashiCorp has used to sign releases; a
+// different set may be supplied to a Client using WithKeyRing.
+type KeyRing struct {
+	keys []SigningKey
+}
+
+// NewKeyRing creates a KeyRing containing the supplied keys. ErrInvalidSigningKey is returned if
+// a fingerprint is malformed or duplicated, or if a validity window ends before it begins.
+func NewKeyRing(keys ...SigningKey) (*KeyRing, error) {
+	ring := &KeyRing{
+		keys: make([]SigningKey, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		fingerprint := strings.ToUpper(strings.ReplaceAll(key.Fingerprint, " ", ""))
+		if decoded, err := hex.DecodeString(fingerprint); err != nil || len(decoded) != 20 {
+			return nil, fmt.Errorf("%w: %q is not a fingerprint", ErrInvalidSigningKey, key.Fingerprint)
+		}
+		if _, ok := ring.Lookup(fingerprint); ok {
+			return nil, fmt.Errorf("%w: %s is duplicated", ErrInvalidSigningKey, fingerprint)
+		}
+		if !key.NotBefore.IsZero() && !key.NotAfter.IsZero() && !key.NotBefore.Before(key.NotAfter) {
+			return nil, fmt.Errorf("%w: validity window of %s is empty", ErrInvalidSigningKey, fingerprint)
+		}
+
+		key.Fingerprint = fingerprint
+		ring.keys = append(ring.keys, key)
+	}
+
+	return ring, nil
+}
+
+// ParseKeyRing creates a KeyRing from its JSON representation, which has the form:
+//
+//	{
+//	  "keys": [
+//	    {
+//	      "fingerprint": "C874011F0AB405110D02105534365D9472D7468F",
+//	      "public_key": "-----BEGIN PGP PUBLIC KEY BLOCK-----\n...",
+//	      "not_before": "2021-04-22T00:00:00Z",
+//	      "not_after": "2031-04-22T00:00:00Z"
+//	    }
+//	  ]
+//	}
+//
+// All members other than fingerprint are optional.
+func ParseKeyRing(r io.Reader) (*KeyRing, error) {
+	var doc struct {
+		Keys []struct {
+			Fingerprint string     `json:"fingerprint"`
+			PublicKey   string     `json:"public_key"`
+			NotBefore   *time.Time `json:"not_before"`
+			NotAfter    *time.Time `json:"not_after"`
+		} `json:"keys"`
+	}
+
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSigningKey, err)
+	}
+
+	keys := make([]SigningKey, 0, len(doc.Keys))
+	for _, entry := range doc.Keys {
+		key := SigningKey{
+			Fingerprint: entry.Fingerprint,
+			PublicKey:   entry.PublicKey,
+		}
+		if entry.NotBefore != nil {
+			key.NotBefore = *entry.NotBefore
+		}
+		if entry.NotAfter != nil {
+			key.NotAfter = *entry.NotAfter
+		}
+		keys = append(keys, key)
+	}
+
+	return NewKeyRing(keys...)
+}
+
+// DefaultKeyRing returns the keys with which HashiCorp signs releases, as embedded in this library:
+// the key with ID 348FFC4C, which is trusted only for releases created before its rotation on 22
+// April 2021, and its replacement with ID 72D7468F, with which earlier releases were also re-signed.
+// The public key of 72D7468F is included; 348FFC4C is known only by fingerprint, since HashiCorp no
+// longer publishes it.
+func DefaultKeyRing() *KeyRing {
+	ring, err := ParseKeyRing(bytes.NewReader(defaultKeyRingJSON))
+	if err != nil {
+		panic(fmt.Sprintf("embedded key ring is invalid: %s", err))
+	}
+	return ring
+}
+
+// Keys returns the keys in the key ring.
+func (k *KeyRing) Keys() []SigningKey {
+	return slices.Clone(k.keys)
+}
+
+// Lookup returns the key identified by id, which may be a short key ID, a long key ID or a
+// fingerprint, and whether it is present in the key ring.
+func (k *KeyRing) Lookup(id string) (SigningKey, bool) {
+	id = strings.ToUpper(strings.ReplaceAll(id, " ", ""))
+	if len(id) < 8 {
+		return SigningKey{}, false
+	}
+
+	for _, key := range k.keys {
+		if strings.HasSuffix(key.Fingerprint, id) {
+			return key, true
+		}
+	}
+	return SigningKey{}, false
+}
+
+// Signature identifies a detached signature of the SHA256SUMS file of a release, and the key with
+// which it should be verified.
+type Signature struct {
+	// URL is the URL from which the signature may be downloaded.
+	URL string
+
+	// Key is the key with which the signature was made.
+	Key SigningKey
+}
+
+// SelectSignature returns the signature of release.URLSHASUMs which should be verified, which is
+// made by a trusted key covering the time at which the release was created. If several such keys
+// signed the release, the one added to the key ring last is preferred, on the basis that keys are
+// added in the order in which they were introduced. Signatures whose filename does not name a key
+// are used only if exactly one trusted key covers the release.
+//
+// If no trusted key covers the release, an error wrapping ErrNoTrustedKey is returned.
+func (k *KeyRing) SelectSignature(release ReleaseInfo) (Signature, error) {
+	var covering []SigningKey
+	for _, key := range k.keys {
+		if key.Covers(release.TimestampCreated) {
+			covering = append(covering, key)
+		}
+	}
+
+	var unnamed string
+	named := map[string]string{}
+	for _, signatureURL := range release.URLSHASUMsSignatures {
+		id, ok := signatureKeyID(signatureURL)
+		switch {
+		case !ok:
+			continue
+		case id == "":
+			unnamed = signatureURL
+		default:
+			named[id] = signatureURL
+		}
+	}
+
+	for _, key := range slices.Backward(covering) {
+		if signatureURL, ok := named[key.ID()]; ok {
+			return Signature{URL: signatureURL, Key: key}, nil
+		}
+	}
+	if len(covering) == 1 && unnamed != "" {
+		return Signature{URL: unnamed, Key: covering[0]}, nil
+	}
+
+	return Signature{}, fmt.Errorf("%w: %s %s created at %s", ErrNoTrustedKey,
+		release.Name, release.Version, release.TimestampCreated.Format(time.RFC3339))
+}
+
+// signatureKeyID returns the key ID named by the filename of a signature, such as 72D7468F for
+// "vault_1.15.0_SHA256SUMS.72D7468F.sig", or an empty string if the filename does not name a key.
+// It returns false if the URL does not refer to a signature.
+func signatureKeyID(signatureURL string) (string, bool) {
+	name := signatureURL
+	if parsed, err := url.Parse(signatureURL); err == nil {
+		name = parsed.Path
+	}
+
+	base, ok := strings.CutSuffix(path.Base(name), ".sig")
+	if !ok {
+		return "", false
+	}
+
+	ext := path.Ext(base)
+	if len(ext) != 9 || !strings.HasSuffix(strings.TrimSuffix(base, ext), "SHA256SUMS") {
+		return "", true
+	}
+	if _, err := hex.DecodeString(ext[1:]); err != nil {
+		return "", true
+	}
+	return strings.ToUpper(ext[1:]), true
+}
+
+// SelectSignature returns the signature of release.URLSHASUMs which should be verified, using the
+// key ring supplied with WithKeyRing, or DefaultKeyRing if none was supplied. See
+// KeyRing.SelectSignature for details. If the release has been withdrawn, a *ReleaseWithdrawnError
+// is returned, unless the Client was constructed using WithAllowWithdrawn.
+func (c *Client) SelectSignature(release ReleaseInfo) (Signature, error) {
+	if err := c.checkWithdrawn(release); err != nil {
+		return Signature{}, err
+	}
+	return c.opts.keyRing.SelectSignature(release)
+}
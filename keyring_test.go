@@ -0,0 +1,247 @@
+package releases_test
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+const (
+	testOldKeyFingerprint = "91A6E7F85D05C65630BEF18951852D87348FFC4C"
+	testNewKeyFingerprint = "C874011F0AB405110D02105534365D9472D7468F"
+)
+
+func TestDefaultKeyRing(t *testing.T) {
+	ring := releases.DefaultKeyRing()
+	requireEqual(t, 2, len(ring.Keys()))
+
+	for _, id := range []string{"72D7468F", "72d7468f", "34365D9472D7468F", "C874 011F 0AB4 0511 0D02 1055 3436 5D94 72D7 468F"} {
+		key, ok := ring.Lookup(id)
+		requireEqual(t, true, ok)
+		requireEqual(t, testNewKeyFingerprint, key.Fingerprint)
+	}
+
+	if _, ok := ring.Lookup("468F"); ok {
+		t.Fatal("IDs shorter than 8 digits must not match")
+	}
+
+	old, ok := ring.Lookup("348FFC4C")
+	requireEqual(t, true, ok)
+	requireEqual(t, true, old.Covers(waypoint_0_1_0.TimestampCreated))
+	requireEqual(t, false, old.Covers(waypoint_0_11_4.TimestampCreated))
+}
+
+func TestKeyRing_SelectSignature(t *testing.T) {
+	oldKeyOnly, err := releases.NewKeyRing(releases.SigningKey{
+		Fingerprint: testOldKeyFingerprint,
+		NotAfter:    time.Date(2021, time.April, 22, 0, 0, 0, 0, time.UTC),
+	})
+	requireNoError(t, err)
+
+	testCases := []struct {
+		name     string
+		ring     *releases.KeyRing
+		release  releases.ReleaseInfo
+		expected string
+	}{
+		{
+			name:     "Current Key",
+			ring:     releases.DefaultKeyRing(),
+			release:  waypoint_0_11_4,
+			expected: "https://releases.hashicorp.com/waypoint/0.11.4/waypoint_0.11.4_SHA256SUMS.72D7468F.sig",
+		},
+		{
+			name:     "Re-signed Release Prefers Newest Key",
+			ring:     releases.DefaultKeyRing(),
+			release:  waypoint_0_1_0,
+			expected: "https://releases.hashicorp.com/waypoint/0.1.0/waypoint_0.1.0_SHA256SUMS.72D7468F.sig",
+		},
+		{
+			name:     "Rotated Key",
+			ring:     oldKeyOnly,
+			release:  waypoint_0_1_0,
+			expected: "https://releases.hashicorp.com/waypoint/0.1.0/waypoint_0.1.0_SHA256SUMS.348FFC4C.sig",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			signature, err := tc.ring.SelectSignature(tc.release)
+			requireNoError(t, err)
+			requireEqual(t, tc.expected, signature.URL)
+			requireEqual(t, strings.TrimSuffix(tc.expected[len(tc.expected)-12:], ".sig"), signature.Key.ID())
+		})
+	}
+
+	t.Run("Unnamed Signature", func(t *testing.T) {
+		release := waypoint_0_1_0
+		release.URLSHASUMsSignatures = release.URLSHASUMsSignatures[:1]
+
+		signature, err := oldKeyOnly.SelectSignature(release)
+		requireNoError(t, err)
+		requireEqual(t, "https://releases.hashicorp.com/waypoint/0.1.0/waypoint_0.1.0_SHA256SUMS.sig", signature.URL)
+
+		// The default key ring has two keys covering this release, so the signer is ambiguous.
+		if _, err := releases.DefaultKeyRing().SelectSignature(release); !errors.Is(err, releases.ErrNoTrustedKey) {
+			t.Fatalf("expected ErrNoTrustedKey, got: %v", err)
+		}
+	})
+
+	t.Run("No Trusted Key", func(t *testing.T) {
+		if _, err := oldKeyOnly.SelectSignature(waypoint_0_11_4); !errors.Is(err, releases.ErrNoTrustedKey) {
+			t.Fatalf("expected ErrNoTrustedKey, got: %v", err)
+		}
+	})
+}
+
+func TestNewKeyRing_Invalid(t *testing.T) {
+	testCases := map[string][]releases.SigningKey{
+		"Short Fingerprint": {{Fingerprint: "72D7468F"}},
+		"Duplicate":         {{Fingerprint: testNewKeyFingerprint}, {Fingerprint: strings.ToLower(testNewKeyFingerprint)}},
+		"Empty Window": {{
+			Fingerprint: testNewKeyFingerprint,
+			NotBefore:   time.Date(2021, time.April, 22, 0, 0, 0, 0, time.UTC),
+			NotAfter:    time.Date(2020, time.April, 22, 0, 0, 0, 0, time.UTC),
+		}},
+	}
+
+	for name, keys := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := releases.NewKeyRing(keys...); !errors.Is(err, releases.ErrInvalidSigningKey) {
+				t.Fatalf("expected ErrInvalidSigningKey, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestParseKeyRing(t *testing.T) {
+	ring, err := releases.ParseKeyRing(strings.NewReader(`{
+		"keys": [{
+			"fingerprint": "c874 011f 0ab4 0511 0d02 1055 3436 5d94 72d7 468f",
+			"public_key": "-----BEGIN PGP PUBLIC KEY BLOCK-----",
+			"not_before": "2021-04-22T00:00:00Z"
+		}]
+	}`))
+	requireNoError(t, err)
+
+	requireEqual(t, []releases.SigningKey{{
+		Fingerprint: testNewKeyFingerprint,
+		PublicKey:   "-----BEGIN PGP PUBLIC KEY BLOCK-----",
+		NotBefore:   time.Date(2021, time.April, 22, 0, 0, 0, 0, time.UTC),
+	}}, ring.Keys())
+
+	if _, err := releases.ParseKeyRing(strings.NewReader(`{"keys": [{"id": "72D7468F"}]}`)); !errors.Is(err, releases.ErrInvalidSigningKey) {
+		t.Fatalf("expected ErrInvalidSigningKey, got: %v", err)
+	}
+}
+
+func TestDefaultKeyRing_PublicKey(t *testing.T) {
+	key, ok := releases.DefaultKeyRing().Lookup("72D7468F")
+	requireEqual(t, true, ok)
+
+	packets := decodeArmoredKey(t, key.PublicKey)
+	if len(packets) < 3 || packets[0].tag != 6 || packets[1].tag != 13 || packets[2].tag != 2 {
+		t.Fatalf("expected a public key, user ID and certification, got %d packets", len(packets))
+	}
+
+	primary, userID, certification := packets[0].body, packets[1].body, packets[2].body
+	fingerprint := sha1.Sum(append([]byte{0x99, byte(len(primary) >> 8), byte(len(primary))}, primary...))
+	requireEqual(t, key.Fingerprint, strings.ToUpper(hex.EncodeToString(fingerprint[:])))
+
+	// The certification binding the user ID to the key is made by the key itself, so verifying it
+	// checks that the embedded key is intact.
+	var signed []byte
+	signed = append(signed, 0x99, byte(len(primary)>>8), byte(len(primary)))
+	signed = append(signed, primary...)
+	signed = append(signed, 0xB4)
+	signed = binary.BigEndian.AppendUint32(signed, uint32(len(userID)))
+	signed = append(signed, userID...)
+	verifyTestSignature(t, primary, certification, signed)
+}
+
+type testPacket struct {
+	tag  byte
+	body []byte
+}
+
+// decodeArmoredKey decodes an ASCII-armored OpenPGP public key, which must use old format packet
+// headers, as HashiCorp's does.
+func decodeArmoredKey(t *testing.T, armored string) []testPacket {
+	t.Helper()
+
+	_, armored, _ = strings.Cut(armored, "-----BEGIN PGP PUBLIC KEY BLOCK-----\n\n")
+	armored, _, _ = strings.Cut(armored, "\n=")
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(armored, "\n", ""))
+	requireNoError(t, err)
+
+	var packets []testPacket
+	for len(data) > 0 {
+		header := data[0]
+		if header&0xC0 != 0x80 || header&0x03 == 0x03 {
+			t.Fatalf("unsupported packet header %#x", header)
+		}
+
+		lengthSize := 1 << (header & 0x03)
+		var length int
+		for _, b := range data[1 : 1+lengthSize] {
+			length = length<<8 | int(b)
+		}
+		data = data[1+lengthSize:]
+
+		packets = append(packets, testPacket{tag: (header >> 2) & 0x0F, body: data[:length]})
+		data = data[length:]
+	}
+	return packets
+}
+
+// readTestMPI reads an OpenPGP multiprecision integer, returning its value and the remaining data.
+func readTestMPI(data []byte) ([]byte, []byte) {
+	length := (int(binary.BigEndian.Uint16(data)) + 7) / 8
+	return data[2 : 2+length], data[2+length:]
+}
+
+// verifyTestSignature verifies a version 4 RSA signature packet, made with SHA-512 by the version 4
+// RSA public key packet key, over signed.
+func verifyTestSignature(t *testing.T, key []byte, signature []byte, signed []byte) {
+	t.Helper()
+
+	if key[0] != 4 || key[5] != 1 {
+		t.Fatalf("expected a version 4 RSA key")
+	}
+	modulus, rest := readTestMPI(key[6:])
+	exponent, _ := readTestMPI(rest)
+	publicKey := &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(new(big.Int).SetBytes(exponent).Int64()),
+	}
+
+	if signature[0] != 4 || signature[2] != 1 || signature[3] != 10 {
+		t.Fatalf("expected a version 4 RSA signature using SHA-512")
+	}
+	hashedLength := 6 + int(binary.BigEndian.Uint16(signature[4:]))
+	unhashedLength := int(binary.BigEndian.Uint16(signature[hashedLength:]))
+	value, _ := readTestMPI(signature[hashedLength+2+unhashedLength+2:])
+
+	hash := sha512.New()
+	hash.Write(signed)
+	hash.Write(signature[:hashedLength])
+	hash.Write([]byte{0x04, 0xFF})
+	hash.Write(binary.BigEndian.AppendUint32(nil, uint32(hashedLength)))
+
+	padded := make([]byte, publicKey.Size())
+	copy(padded[len(padded)-len(value):], value)
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA512, hash.Sum(nil), padded); err != nil {
+		t.Fatalf("signature verification failed: %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package releases
+
+import (
+	"context"
+	"iter"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// LoggingAPI is a ReleasesAPI which logs each call to another ReleasesAPI, such as a Client, along
+// with its duration and any error. Successful calls are logged at debug level, and failed calls at
+// error level. For Releases and ReleasesPaged, a further entry is logged when iteration finishes,
+// with the number of releases or pages yielded.
+type LoggingAPI struct {
+	api    ReleasesAPI
+	logger *slog.Logger
+}
+
+var _ ReleasesAPI = (*LoggingAPI)(nil)
+
+// NewLoggingAPI returns a LoggingAPI which logs calls to api using logger. If logger is nil,
+// slog.Default is used.
+func NewLoggingAPI(api ReleasesAPI, logger *slog.Logger) *LoggingAPI {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &LoggingAPI{
+		api:    api,
+		logger: logger,
+	}
+}
+
+// Products returns the names of all products tracked by the API.
+func (l *LoggingAPI) Products(ctx context.Context, opts ...CallOpt) ([]string, error) {
+	start := time.Now()
+	products, err := l.api.Products(ctx, opts...)
+	l.log(ctx, "Products", start, err, slog.Int("products", len(products)))
+	return products, err
+}
+
+// Release returns all metadata for a specific version of a product.
+func (l *LoggingAPI) Release(ctx context.Context, product string, version string, opts ...CallOpt) (ReleaseInfo, error) {
+	start := time.Now()
+	release, err := l.api.Release(ctx, product, version, opts...)
+	l.log(ctx, "Release", start, err, slog.String("product", product), slog.String("version", version))
+	return release, err
+}
+
+// LatestRelease returns all metadata for the latest release of a product with the given license
+// class, or of any license class if licenseClass is nil.
+func (l *LoggingAPI) LatestRelease(ctx context.Context, product string, licenseClass *LicenseClass, opts ...CallOpt) (ReleaseInfo, error) {
+	start := time.Now()
+	release, err := l.api.LatestRelease(ctx, product, licenseClass, opts...)
+	l.log(ctx, "LatestRelease", start, err,
+		slog.String("product", product),
+		slog.String("license_class", licenseClassKey(licenseClass)),
+		slog.String("version", release.Version))
+	return release, err
+}
+
+// Releases returns an iterator over the releases of a product with the given license class,
+// newest first.
+func (l *LoggingAPI) Releases(ctx context.Context, product string, licenseClass *LicenseClass, opts ...CallOpt) (iter.Seq2[ReleaseInfo, error], error) {
+	attrs := []slog.Attr{
+		slog.String("product", product),
+		slog.String("license_class", licenseClassKey(licenseClass)),
+	}
+
+	start := time.Now()
+	items, err := l.api.Releases(ctx, product, licenseClass, opts...)
+	l.log(ctx, "Releases", start, err, attrs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(ReleaseInfo, error) bool) {
+		start := time.Now()
+		count := 0
+		var iterErr error
+		defer func() {
+			l.log(ctx, "Releases iteration", start, iterErr, append(attrs, slog.Int("releases", count))...)
+		}()
+
+		for item, err := range items {
+			if err != nil {
+				iterErr = err
+			} else {
+				count++
+			}
+			if !yield(item, err) {
+				return
+			}
+		}
+	}, nil
+}
+
+// ReleasesPaged returns an iterator over pages of the releases of a product with the given license
+// class, newest first.
+func (l *LoggingAPI) ReleasesPaged(ctx context.Context, product string, licenseClass *LicenseClass, opts ...CallOpt) (iter.Seq2[[]ReleaseInfo, error], error) {
+	attrs := []slog.Attr{
+		slog.String("product", product),
+		slog.String("license_class", licenseClassKey(licenseClass)),
+	}
+
+	start := time.Now()
+	pages, err := l.api.ReleasesPaged(ctx, product, licenseClass, opts...)
+	l.log(ctx, "ReleasesPaged", start, err, attrs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func([]ReleaseInfo, error) bool) {
+		start := time.Now()
+		count := 0
+		var iterErr error
+		defer func() {
+			l.log(ctx, "ReleasesPaged iteration", start, iterErr, append(attrs, slog.Int("pages", count))...)
+		}()
+
+		for page, err := range pages {
+			if err != nil {
+				iterErr = err
+			} else {
+				count++
+			}
+			if !yield(page, err) {
+				return
+			}
+		}
+	}, nil
+}
+
+func (l *LoggingAPI) log(ctx context.Context, method string, start time.Time, err error, attrs ...slog.Attr) {
+	level := slog.LevelDebug
+	attrs = append(slices.Clip(attrs), slog.Duration("duration", time.Since(start)))
+	if err != nil {
+		level = slog.LevelError
+		attrs = append(attrs, slog.Any("error", err))
+	}
+
+	l.logger.LogAttrs(ctx, level, "releases: "+method, attrs...)
+}
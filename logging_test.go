@@ -0,0 +1,47 @@
+package releases_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+func TestLoggingAPI(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == "duration" {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	}))
+
+	api := releases.NewLoggingAPI(releases.NewSnapshot(waypointReleases...), logger)
+	ctx := context.Background()
+
+	_, err := api.Release(ctx, "waypoint", "0.11.4")
+	requireNoError(t, err)
+
+	_, err = api.Release(ctx, "waypoint", "0.0.1")
+	if err == nil {
+		t.Fatal("expected an error for an unknown release")
+	}
+
+	items, err := api.Releases(ctx, "waypoint", releases.LicenseClassOSS)
+	requireNoError(t, err)
+	requireEqual(t, 43, len(collectResults(t, items)))
+
+	expected := []string{
+		`level=DEBUG msg="releases: Release" product=waypoint version=0.11.4`,
+		`level=ERROR msg="releases: Release" product=waypoint version=0.0.1 error="not found: release 0.0.1 of waypoint"`,
+		`level=DEBUG msg="releases: Releases" product=waypoint license_class=oss`,
+		`level=DEBUG msg="releases: Releases iteration" product=waypoint license_class=oss releases=43`,
+	}
+	requireEqual(t, expected, strings.Split(strings.TrimSpace(buf.String()), "\n"))
+}
@@ -0,0 +1,252 @@
+// Package mirror implements the Terraform provider network mirror protocol, serving the providers
+// published to the HashiCorp Releases API, such as terraform-provider-aws, to Terraform.
+//
+// See https://developer.hashicorp.com/terraform/internals/provider-network-mirror-protocol for a
+// description of the protocol.
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+const (
+	// DefaultHostname is the hostname of the public Terraform registry, under which HashiCorp
+	// providers are addressed unless configured otherwise.
+	DefaultHostname = "registry.terraform.io"
+
+	// Namespace is the registry namespace of the providers published to the Releases API.
+	Namespace = "hashicorp"
+
+	productPrefix = "terraform-provider-"
+)
+
+// ErrNoHostnames indicates that WithHostnames was supplied no hostnames.
+var ErrNoHostnames = errors.New("no hostnames")
+
+var providerTypePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Index is the response to a request for the versions of a provider available from a mirror.
+type Index struct {
+	// Versions holds an empty object for each available version.
+	Versions map[string]struct{} `json:"versions"`
+}
+
+// Archive describes the package of a provider version for a single platform.
+type Archive struct {
+	// URL is the URL from which the package may be downloaded.
+	URL string `json:"url"`
+
+	// Hashes holds the hashes of the package which Terraform records in its dependency lock file.
+	// Hashes derived from SHA256SUMS use the "zh:" scheme.
+	Hashes []string `json:"hashes,omitempty"`
+}
+
+// Version is the response to a request for the packages of a single provider version.
+type Version struct {
+	// Archives holds the package for each platform, keyed by "<os>_<arch>".
+	Archives map[string]Archive `json:"archives"`
+}
+
+// HandlerOpt is a functional option which can be used to configure the http.Handler returned by
+// the Handler function.
+type HandlerOpt func(*handlerOpts) error
+
+type handlerOpts struct {
+	hostnames map[string]struct{}
+	logger    *slog.Logger
+}
+
+// WithHostnames sets the registry hostnames under which providers are served. If unset, only
+// requests for providers under registry.terraform.io are served. An error wrapping ErrNoHostnames
+// is returned if no hostnames are supplied.
+func WithHostnames(hostnames ...string) HandlerOpt {
+	return func(opts *handlerOpts) error {
+		if len(hostnames) == 0 {
+			return fmt.Errorf("%w: at least one hostname must be supplied", ErrNoHostnames)
+		}
+		opts.hostnames = make(map[string]struct{}, len(hostnames))
+		for _, hostname := range hostnames {
+			opts.hostnames[strings.ToLower(hostname)] = struct{}{}
+		}
+		return nil
+	}
+}
+
+// WithLogger sets the logger to which errors retrieving releases and checksums are written, since
+// the response reports only their status. If unset, or if logger is nil, slog.Default is used.
+func WithLogger(logger *slog.Logger) HandlerOpt {
+	return func(opts *handlerOpts) error {
+		opts.logger = logger
+		return nil
+	}
+}
+
+// Client is the set of operations used by Handler to retrieve releases and their checksums. It is
+// satisfied by *releases.Client. Implementations of releases.ReleasesAPI such as CachingAPI and
+// Snapshot do not provide Checksums, so must be combined with a type which does.
+type Client interface {
+	releases.ReleasesAPI
+
+	// Checksums retrieves and parses the SHA256SUMS file referenced by release.
+	Checksums(ctx context.Context, release releases.ReleaseInfo) (releases.Checksums, error)
+}
+
+type handler struct {
+	client Client
+	opts   handlerOpts
+}
+
+// Handler returns an http.Handler which implements the provider network mirror protocol, using
+// client to retrieve releases. The final four elements of the request path select the provider and
+// the requested document: a request for "/providers/registry.terraform.io/hashicorp/aws/index.json"
+// is served the versions of terraform-provider-aws, and a request for
+// "/providers/registry.terraform.io/hashicorp/aws/5.31.0.json" the packages of that version, with
+// hashes taken from its SHA256SUMS file using Client.Checksums. The handler may therefore be
+// mounted at any prefix, which is then used as the mirror URL in the Terraform CLI configuration.
+//
+// Only providers in the hashicorp namespace are served. Withdrawn releases are omitted from the
+// version index, and requests for them are rejected as not found.
+func Handler(client Client, opts ...HandlerOpt) (http.Handler, error) {
+	effectiveOpts := handlerOpts{
+		hostnames: map[string]struct{}{DefaultHostname: {}},
+	}
+	for _, opt := range opts {
+		if err := opt(&effectiveOpts); err != nil {
+			return nil, err
+		}
+	}
+	if effectiveOpts.logger == nil {
+		effectiveOpts.logger = slog.Default()
+	}
+
+	return &handler{
+		client: client,
+		opts:   effectiveOpts,
+	}, nil
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	elems := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	if len(elems) < 4 {
+		http.NotFound(w, r)
+		return
+	}
+	hostname, namespace, providerType, name := elems[len(elems)-4], elems[len(elems)-3], elems[len(elems)-2], elems[len(elems)-1]
+
+	if _, ok := h.opts.hostnames[strings.ToLower(hostname)]; !ok ||
+		namespace != Namespace ||
+		!providerTypePattern.MatchString(providerType) ||
+		path.Ext(name) != ".json" {
+		http.NotFound(w, r)
+		return
+	}
+	product := productPrefix + providerType
+
+	var body any
+	var err error
+	if name == "index.json" {
+		body, err = h.index(r.Context(), product)
+	} else {
+		body, err = h.version(r.Context(), product, strings.TrimSuffix(name, ".json"))
+	}
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_, _ = buf.WriteTo(w)
+	}
+}
+
+func (h *handler) index(ctx context.Context, product string) (Index, error) {
+	items, err := h.client.Releases(ctx, product, releases.LicenseClassAny)
+	if err != nil {
+		return Index{}, err
+	}
+
+	index := Index{
+		Versions: map[string]struct{}{},
+	}
+	for item, err := range items {
+		if err != nil {
+			return Index{}, err
+		}
+		if item.Status.State == releases.ReleaseStateWithdrawn {
+			continue
+		}
+		index.Versions[item.Version] = struct{}{}
+	}
+
+	if len(index.Versions) == 0 {
+		return Index{}, releases.ErrNotFound
+	}
+	return index, nil
+}
+
+func (h *handler) version(ctx context.Context, product string, version string) (Version, error) {
+	release, err := h.client.Release(ctx, product, version)
+	if err != nil {
+		return Version{}, err
+	}
+	if err := release.CheckWithdrawn(); err != nil {
+		return Version{}, fmt.Errorf("%w: %w", releases.ErrNotFound, err)
+	}
+
+	checksums, err := h.client.Checksums(ctx, release)
+	if err != nil {
+		return Version{}, err
+	}
+
+	result := Version{
+		Archives: make(map[string]Archive, len(release.Builds)),
+	}
+	for _, build := range release.Builds {
+		sum, ok := checksums.LookupBuild(build)
+		if !ok {
+			return Version{}, fmt.Errorf("no checksum for %s in %s", build.URL, release.URLSHASUMs)
+		}
+
+		result.Archives[build.OS+"_"+build.Arch] = Archive{
+			URL:    build.URL,
+			Hashes: []string{sum.ZH()},
+		}
+	}
+	return result, nil
+}
+
+func (h *handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, releases.ErrNotFound), errors.Is(err, releases.ErrInvalidProduct),
+		errors.Is(err, releases.ErrInvalidVersion):
+		http.NotFound(w, r)
+	default:
+		h.opts.logger.ErrorContext(r.Context(), "mirror: retrieving provider", slog.String("path", r.URL.Path), slog.Any("error", err))
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+	}
+}
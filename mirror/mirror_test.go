@@ -0,0 +1,199 @@
+package mirror_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+	"github.com/jen20/go-hashicorp-releases-client/mirror"
+)
+
+const testChecksums = `4fd4a0d9b5e1ab1ff0f4ac2bbd5c2e7d6e0d5cc3b9bd49a3d5c1a2a7f3f0c111  terraform-provider-null_3.2.0_darwin_arm64.zip
+8a6dc2b4a5cbd47d9a2a1dbb1d0a7c7bb0b4ad85bd3e8a4a10f2e3c1c9e3d222  terraform-provider-null_3.2.0_linux_amd64.zip
+`
+
+func newTestAPI(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+
+	makeRelease := func(version string, state releases.ReleaseState) releases.ReleaseInfo {
+		return releases.ReleaseInfo{
+			Name:    "terraform-provider-null",
+			Version: version,
+			Status:  releases.ReleaseStatus{State: state, Message: "withdrawn for testing"},
+			Builds: []releases.BuildInfo{
+				{OS: "darwin", Arch: "arm64", URL: fmt.Sprintf("%s/terraform-provider-null_%s_darwin_arm64.zip", server.URL, version)},
+				{OS: "linux", Arch: "amd64", URL: fmt.Sprintf("%s/terraform-provider-null_%s_linux_amd64.zip", server.URL, version)},
+			},
+			TimestampCreated: time.Date(2023, time.October, 20, 10, 0, 0, 0, time.UTC),
+			URLSHASUMs:       server.URL + "/terraform-provider-null_" + version + "_SHA256SUMS",
+		}
+	}
+
+	encode := func(w http.ResponseWriter, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			t.Errorf("failed to encode response: %v", err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/releases/terraform-provider-null", func(w http.ResponseWriter, r *http.Request) {
+		encode(w, []releases.ReleaseInfo{
+			makeRelease("3.2.0", releases.ReleaseStateSupported),
+			makeRelease("3.1.0", releases.ReleaseStateWithdrawn),
+		})
+	})
+	mux.HandleFunc("GET /v1/releases/terraform-provider-null/3.2.0", func(w http.ResponseWriter, r *http.Request) {
+		encode(w, makeRelease("3.2.0", releases.ReleaseStateSupported))
+	})
+	mux.HandleFunc("GET /v1/releases/terraform-provider-null/3.1.0", func(w http.ResponseWriter, r *http.Request) {
+		encode(w, makeRelease("3.1.0", releases.ReleaseStateWithdrawn))
+	})
+	mux.HandleFunc("GET /terraform-provider-null_3.2.0_SHA256SUMS", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, testChecksums)
+	})
+	mux.HandleFunc("GET /v1/releases/{product}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("GET /v1/releases/{product}/{version}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	server = httptest.NewServer(mux)
+	return server
+}
+
+func TestHandler(t *testing.T) {
+	server := newTestAPI(t)
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("unexpected error constructing client: %v", err)
+	}
+
+	handler, err := mirror.Handler(client, mirror.WithHostnames(mirror.DefaultHostname, "terraform.example.com"))
+	if err != nil {
+		t.Fatalf("unexpected error constructing handler: %v", err)
+	}
+
+	t.Run("Index", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/providers/registry.terraform.io/hashicorp/null/index.json", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		var index mirror.Index
+		if err := json.Unmarshal(rec.Body.Bytes(), &index); err != nil {
+			t.Fatalf("response is not valid JSON: %v", err)
+		}
+		expected := mirror.Index{Versions: map[string]struct{}{"3.2.0": {}}}
+		if !reflect.DeepEqual(expected, index) {
+			t.Fatalf("expected %v, got %v", expected, index)
+		}
+	})
+
+	t.Run("Version", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/terraform.example.com/hashicorp/null/3.2.0.json", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		var version mirror.Version
+		if err := json.Unmarshal(rec.Body.Bytes(), &version); err != nil {
+			t.Fatalf("response is not valid JSON: %v", err)
+		}
+		expected := mirror.Version{Archives: map[string]mirror.Archive{
+			"darwin_arm64": {
+				URL:    server.URL + "/terraform-provider-null_3.2.0_darwin_arm64.zip",
+				Hashes: []string{"zh:4fd4a0d9b5e1ab1ff0f4ac2bbd5c2e7d6e0d5cc3b9bd49a3d5c1a2a7f3f0c111"},
+			},
+			"linux_amd64": {
+				URL:    server.URL + "/terraform-provider-null_3.2.0_linux_amd64.zip",
+				Hashes: []string{"zh:8a6dc2b4a5cbd47d9a2a1dbb1d0a7c7bb0b4ad85bd3e8a4a10f2e3c1c9e3d222"},
+			},
+		}}
+		if !reflect.DeepEqual(expected, version) {
+			t.Fatalf("expected %v, got %v", expected, version)
+		}
+	})
+
+	testCases := []struct {
+		path   string
+		status int
+	}{
+		{path: "/registry.terraform.io/hashicorp/null/3.1.0.json", status: http.StatusNotFound},
+		{path: "/registry.terraform.io/hashicorp/null/9.9.9.json", status: http.StatusNotFound},
+		{path: "/registry.terraform.io/hashicorp/unknown/index.json", status: http.StatusNotFound},
+		{path: "/registry.terraform.io/example/null/index.json", status: http.StatusNotFound},
+		{path: "/registry.example.com/hashicorp/null/index.json", status: http.StatusNotFound},
+		{path: "/registry.terraform.io/hashicorp/null/index.xml", status: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if rec.Code != tc.status {
+				t.Fatalf("expected status %d, got %d: %s", tc.status, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestWithHostnames_Empty(t *testing.T) {
+	_, err := mirror.Handler(nil, mirror.WithHostnames())
+	if !errors.Is(err, mirror.ErrNoHostnames) {
+		t.Fatalf("expected error wrapping ErrNoHostnames, got: %v", err)
+	}
+}
+
+func TestHandler_UpstreamError(t *testing.T) {
+	server := newTestAPI(t)
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("unexpected error constructing client: %v", err)
+	}
+
+	var logs bytes.Buffer
+	handler, err := mirror.Handler(&brokenChecksumsClient{Client: client}, mirror.WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+	if err != nil {
+		t.Fatalf("unexpected error constructing handler: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/registry.terraform.io/hashicorp/null/3.2.0.json", nil))
+
+	if rec.Code != http.StatusBadGateway {
+		t.Fatalf("expected status %d, got %d", http.StatusBadGateway, rec.Code)
+	}
+	if body := strings.TrimSpace(rec.Body.String()); body != http.StatusText(http.StatusBadGateway) {
+		t.Fatalf("upstream error detail must not be sent, got %q", body)
+	}
+	if !strings.Contains(logs.String(), "internal.example.com") {
+		t.Fatalf("upstream error detail must be logged, got %q", logs.String())
+	}
+}
+
+// brokenChecksumsClient fails to retrieve checksums with an error revealing internal details.
+type brokenChecksumsClient struct {
+	*releases.Client
+}
+
+func (c *brokenChecksumsClient) Checksums(context.Context, releases.ReleaseInfo) (releases.Checksums, error) {
+	return releases.Checksums{}, errors.New("GET https://internal.example.com/SHA256SUMS: connection refused")
+}
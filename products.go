@@ -4,25 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"path"
+	"slices"
 )
 
 // Products returns a slice of the HashiCorp products for which release information may be obtained.
-func (c *Client) Products(ctx context.Context) ([]string, error) {
-	const mediaType = "application/vnd+hashicorp.releases-api.v1+json"
-
-	reqURL := c.makeURL(path.Join("v1", "products"), nil)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+func (c *Client) Products(ctx context.Context, opts ...CallOpt) ([]string, error) {
+	effectiveOpts, err := c.newCallOpts(opts...)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrConstructingRequest, err)
-	}
-	req.Header.Set("Accept", mediaType)
-	if c.opts.userAgent != nil {
-		req.Header.Set("User-Agent", *c.opts.userAgent)
+		return nil, err
 	}
 
-	resp, err := c.opts.httpClient.Do(req)
+	resp, _, err := c.get(ctx, path.Join("v1", "products"), nil, effectiveOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -30,11 +23,8 @@ func (c *Client) Products(ctx context.Context) ([]string, error) {
 		_ = resp.Body.Close()
 	}()
 
-	if contentType := resp.Header.Get("Content-Type"); contentType != mediaType {
-		if contentType == "" {
-			contentType = "<none>"
-		}
-		return nil, fmt.Errorf("%w: %s", ErrInvalidResponseContentType, contentType)
+	if err := checkContentType(resp); err != nil {
+		return nil, err
 	}
 
 	var body []string
@@ -42,5 +32,9 @@ func (c *Client) Products(ctx context.Context) ([]string, error) {
 		return nil, fmt.Errorf("%w: %w", ErrInvalidResponseBody, err)
 	}
 
+	if c.opts.strictDecoding && slices.Contains(body, "") {
+		return nil, fmt.Errorf("%w: empty product name", ErrInvalidResponseBody)
+	}
+
 	return body, nil
 }
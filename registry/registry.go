@@ -0,0 +1,400 @@
+// Package registry provides a minimal client for the OCI Distribution API, sufficient to resolve
+// the container images of HashiCorp releases to manifest digests and to list the platforms for
+// which they are available.
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+const (
+	mediaTypeOCIIndex          = "application/vnd.oci.image.index.v1+json"
+	mediaTypeOCIManifest       = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerList        = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerManifest    = "application/vnd.docker.distribution.manifest.v2+json"
+	maxManifestBytes           = 4 * 1024 * 1024
+	dockerHubRegistryAPIServer = "https://registry-1.docker.io"
+)
+
+var (
+	// ErrManifestNotFound indicates that the registry has no manifest for the requested reference.
+	ErrManifestNotFound = errors.New("manifest not found")
+
+	// ErrUnexpectedResponse indicates that the registry returned a response which does not conform
+	// to the OCI Distribution API.
+	ErrUnexpectedResponse = errors.New("unexpected registry response")
+
+	// ErrDigestMismatch indicates that the content of a manifest does not match its digest.
+	ErrDigestMismatch = errors.New("manifest digest mismatch")
+)
+
+// Platform identifies an operating system and CPU architecture for which an image is available.
+type Platform struct {
+	// OS is the operating system, for example "linux".
+	OS string `json:"os"`
+
+	// Architecture is the CPU architecture, for example "amd64".
+	Architecture string `json:"architecture"`
+
+	// Variant is the variant of the CPU architecture, for example "v7" for "arm". It is often empty.
+	Variant string `json:"variant,omitempty"`
+}
+
+func (p Platform) String() string {
+	if p.Variant == "" {
+		return p.OS + "/" + p.Architecture
+	}
+	return p.OS + "/" + p.Architecture + "/" + p.Variant
+}
+
+// Manifest describes the manifest to which an image reference resolves.
+type Manifest struct {
+	// Digest is the content digest of the manifest, for example "sha256:4e2d...". It may be used
+	// to pin the image.
+	Digest string
+
+	// MediaType is the media type of the manifest, which is either an image index or a single
+	// image manifest.
+	MediaType string
+
+	// Platforms lists the platforms for which the image is available. Attestation manifests, which
+	// some registries list with the platform "unknown/unknown", are omitted.
+	Platforms []Platform
+}
+
+// Opt is a functional option which can be used to configure a Client via the New function.
+type Opt func(*clientOpts) error
+
+type clientOpts struct {
+	httpClient *http.Client
+	userAgent  string
+	endpoints  map[string]string
+}
+
+// WithHTTPClient configures a custom [http.Client] with which to make requests. If this option is
+// not supplied, or httpClient is set to nil, [http.DefaultClient] will be used.
+func WithHTTPClient(httpClient *http.Client) Opt {
+	return func(opts *clientOpts) error {
+		if httpClient != nil {
+			opts.httpClient = httpClient
+		}
+		return nil
+	}
+}
+
+// WithUserAgent configures the value of the HTTP User-Agent header to send with requests. If this
+// option is not supplied, the default User-Agent of net/http is sent.
+func WithUserAgent(userAgent string) Opt {
+	return func(opts *clientOpts) error {
+		opts.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithEndpoint sets the base URL at which the Distribution API of the named registry may be
+// reached, for example to direct requests for "docker.io" to a pull-through cache, or to a local
+// registry in tests. By default, Docker Hub is reached at https://registry-1.docker.io, and other
+// registries at https://<registry>.
+func WithEndpoint(registry string, baseURL string) Opt {
+	return func(opts *clientOpts) error {
+		parsed, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		opts.endpoints[registry] = strings.TrimSuffix(parsed.String(), "/")
+		return nil
+	}
+}
+
+// Client provides a handle to interact with container registries via the OCI Distribution API.
+// Anonymous bearer tokens are obtained automatically for registries which require them, as Docker
+// Hub and the public AWS Elastic Container Registry do.
+type Client struct {
+	opts clientOpts
+
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+// New creates a new Client, and uses the supplied options to configure it.
+func New(opts ...Opt) (*Client, error) {
+	effectiveOpts := clientOpts{
+		httpClient: http.DefaultClient,
+		endpoints: map[string]string{
+			releases.RegistryDockerHub: dockerHubRegistryAPIServer,
+		},
+	}
+	for _, opt := range opts {
+		if err := opt(&effectiveOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Client{
+		opts:   effectiveOpts,
+		tokens: make(map[string]string),
+	}, nil
+}
+
+// Resolve returns the manifest to which ref refers. If ref has a digest, the manifest with that
+// digest is retrieved, and its content is verified against it; otherwise the manifest with the tag
+// is retrieved. If ref has neither a tag nor a digest, an error wrapping releases.ErrInvalidImageRef
+// is returned.
+func (c *Client) Resolve(ctx context.Context, ref releases.ImageRef) (Manifest, error) {
+	if ref.Tag == "" && ref.Digest == "" {
+		return Manifest{}, fmt.Errorf("%w: %s has neither a tag nor a digest", releases.ErrInvalidImageRef, ref)
+	}
+
+	reference := ref.Tag
+	if ref.Digest != "" {
+		reference = ref.Digest
+	}
+
+	body, mediaType, digest, err := c.fetch(ctx, ref, "manifests", reference,
+		strings.Join([]string{mediaTypeOCIIndex, mediaTypeDockerList, mediaTypeOCIManifest, mediaTypeDockerManifest}, ", "))
+	if err != nil {
+		return Manifest{}, err
+	}
+	if ref.Digest != "" && digest != ref.Digest {
+		return Manifest{}, fmt.Errorf("%w: expected %s, got %s", ErrDigestMismatch, ref.Digest, digest)
+	}
+
+	var document struct {
+		MediaType string `json:"mediaType"`
+		Manifests []struct {
+			Platform *Platform `json:"platform"`
+		} `json:"manifests"`
+		Config struct {
+			Digest string `json:"digest"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return Manifest{}, fmt.Errorf("%w: %w", ErrUnexpectedResponse, err)
+	}
+	if mediaType == "" {
+		mediaType = document.MediaType
+	}
+
+	manifest := Manifest{
+		Digest:    digest,
+		MediaType: mediaType,
+	}
+
+	switch mediaType {
+	case mediaTypeOCIIndex, mediaTypeDockerList:
+		for _, entry := range document.Manifests {
+			if entry.Platform == nil || entry.Platform.OS == "unknown" {
+				continue
+			}
+			manifest.Platforms = append(manifest.Platforms, *entry.Platform)
+		}
+	case mediaTypeOCIManifest, mediaTypeDockerManifest:
+		configBody, _, _, err := c.fetch(ctx, ref, "blobs", document.Config.Digest, "*/*")
+		if err != nil {
+			return Manifest{}, err
+		}
+
+		var platform Platform
+		if err := json.Unmarshal(configBody, &platform); err != nil {
+			return Manifest{}, fmt.Errorf("%w: %w", ErrUnexpectedResponse, err)
+		}
+		manifest.Platforms = []Platform{platform}
+	default:
+		return Manifest{}, fmt.Errorf("%w: unsupported manifest media type %q", ErrUnexpectedResponse, mediaType)
+	}
+
+	return manifest, nil
+}
+
+// fetch retrieves a manifest or blob, authenticating with an anonymous bearer token if the
+// registry requires it. It returns the body, its media type and its digest, which is computed using
+// the algorithm of the digest by which it was requested or, if it was requested by tag, of the
+// digest reported by the registry, and otherwise using SHA-256.
+func (c *Client) fetch(ctx context.Context, ref releases.ImageRef, kind string, reference string, accept string) ([]byte, string, string, error) {
+	endpoint := c.endpoint(ref.Registry)
+	target := fmt.Sprintf("%s/v2/%s/%s/%s", endpoint, ref.Repository, kind, reference)
+
+	resp, err := c.get(ctx, target, accept, c.token(endpoint, ref.Repository))
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+
+		token, err := c.authenticate(ctx, challenge)
+		if err != nil {
+			return nil, "", "", err
+		}
+		c.setToken(endpoint, ref.Repository, token)
+
+		resp, err = c.get(ctx, target, accept, token)
+		if err != nil {
+			return nil, "", "", err
+		}
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, "", "", fmt.Errorf("%w: %s", ErrManifestNotFound, ref)
+	default:
+		return nil, "", "", fmt.Errorf("%w: status %d for %s", ErrUnexpectedResponse, resp.StatusCode, target)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestBytes+1))
+	if err != nil {
+		return nil, "", "", err
+	}
+	if len(body) > maxManifestBytes {
+		return nil, "", "", fmt.Errorf("%w: %s exceeds %d bytes", ErrUnexpectedResponse, target, maxManifestBytes)
+	}
+
+	header := resp.Header.Get("Docker-Content-Digest")
+	algorithm, _, found := strings.Cut(reference, ":")
+	if !found {
+		algorithm, _, _ = strings.Cut(header, ":")
+	}
+	digest, err := computeDigest(algorithm, body)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if header != "" && header != digest {
+		return nil, "", "", fmt.Errorf("%w: registry reported %s, content is %s", ErrDigestMismatch, header, digest)
+	}
+
+	mediaType, _, _ := strings.Cut(resp.Header.Get("Content-Type"), ";")
+	return body, strings.TrimSpace(mediaType), digest, nil
+}
+
+// computeDigest returns the digest of content using the named algorithm, which may be "sha256" or
+// "sha512". If algorithm is empty, "sha256" is used.
+func computeDigest(algorithm string, content []byte) (string, error) {
+	var h hash.Hash
+	switch algorithm {
+	case "", "sha256":
+		algorithm, h = "sha256", sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return "", fmt.Errorf("%w: unsupported digest algorithm %q", ErrUnexpectedResponse, algorithm)
+	}
+
+	h.Write(content)
+	return algorithm + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (c *Client) get(ctx context.Context, target string, accept string, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", releases.ErrConstructingRequest, err)
+	}
+	req.Header.Set("Accept", accept)
+	if c.opts.userAgent != "" {
+		req.Header.Set("User-Agent", c.opts.userAgent)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return c.opts.httpClient.Do(req)
+}
+
+// authenticate obtains an anonymous bearer token as directed by a WWW-Authenticate challenge of
+// the form `Bearer realm="...",service="...",scope="..."`.
+func (c *Client) authenticate(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("%w: unsupported authentication challenge %q", ErrUnexpectedResponse, challenge)
+	}
+
+	values := parseChallengeParams(params)
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Scheme == "" {
+		return "", fmt.Errorf("%w: invalid authentication realm %q", ErrUnexpectedResponse, values["realm"])
+	}
+
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if value, ok := values[key]; ok {
+			query.Set(key, value)
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	resp, err := c.get(ctx, realm.String(), "application/json", "")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: status %d obtaining token", ErrUnexpectedResponse, resp.StatusCode)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrUnexpectedResponse, err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+func parseChallengeParams(params string) map[string]string {
+	values := make(map[string]string)
+	for params != "" {
+		var key, value string
+		key, params, _ = strings.Cut(strings.TrimLeft(params, ", "), "=")
+		if strings.HasPrefix(params, `"`) {
+			value, params, _ = strings.Cut(params[1:], `"`)
+		} else {
+			value, params, _ = strings.Cut(params, ",")
+		}
+		values[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+	return values
+}
+
+func (c *Client) endpoint(registry string) string {
+	if endpoint, ok := c.opts.endpoints[registry]; ok {
+		return endpoint
+	}
+	return "https://" + registry
+}
+
+func (c *Client) token(endpoint string, repository string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tokens[endpoint+"/"+repository]
+}
+
+func (c *Client) setToken(endpoint string, repository string, token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tokens[endpoint+"/"+repository] = token
+}
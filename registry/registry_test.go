@@ -0,0 +1,185 @@
+package registry_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+	"github.com/jen20/go-hashicorp-releases-client/registry"
+)
+
+const testIndex = `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.index.v1+json",
+  "manifests": [
+    {"digest": "sha256:1111", "platform": {"os": "linux", "architecture": "amd64"}},
+    {"digest": "sha256:2222", "platform": {"os": "linux", "architecture": "arm", "variant": "v7"}},
+    {"digest": "sha256:3333", "platform": {"os": "unknown", "architecture": "unknown"}}
+  ]
+}`
+
+const testManifest = `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+  "config": {"digest": "sha256:c0nf16"}
+}`
+
+func digestOf(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func sha512DigestOf(content string) string {
+	sum := sha512.Sum512([]byte(content))
+	return "sha512:" + hex.EncodeToString(sum[:])
+}
+
+// newTestRegistry returns a server which emulates a registry requiring anonymous bearer tokens,
+// in the manner of Docker Hub.
+func newTestRegistry(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /token", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("scope") != "repository:hashicorp/vault:pull" {
+			t.Errorf("unexpected scope %q", r.URL.Query().Get("scope"))
+		}
+		_, _ = fmt.Fprint(w, `{"token": "anonymous-token"}`)
+	})
+
+	serveWithDigest := func(mediaType string, content string, digest string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer anonymous-token" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(
+					`Bearer realm="%s/token",service="registry.test",scope="repository:hashicorp/vault:pull"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", mediaType)
+			w.Header().Set("Docker-Content-Digest", digest)
+			_, _ = fmt.Fprint(w, content)
+		}
+	}
+	serve := func(mediaType string, content string) http.HandlerFunc {
+		return serveWithDigest(mediaType, content, digestOf(content))
+	}
+	mux.Handle("GET /v2/hashicorp/vault/manifests/1.15.0", serve("application/vnd.oci.image.index.v1+json", testIndex))
+	mux.Handle("GET /v2/hashicorp/vault/manifests/"+digestOf(testIndex), serve("application/vnd.oci.image.index.v1+json", testIndex))
+	mux.Handle("GET /v2/hashicorp/vault/manifests/"+sha512DigestOf(testIndex),
+		serveWithDigest("application/vnd.oci.image.index.v1+json", testIndex, sha512DigestOf(testIndex)))
+	mux.Handle("GET /v2/hashicorp/vault/manifests/oversized",
+		serve("application/vnd.oci.image.index.v1+json", testIndex+strings.Repeat(" ", 4*1024*1024)))
+	mux.Handle("GET /v2/hashicorp/vault/manifests/1.14.0", serve("application/vnd.docker.distribution.manifest.v2+json", testManifest))
+	mux.Handle("GET /v2/hashicorp/vault/blobs/sha256:c0nf16", serve("application/octet-stream", `{"os": "linux", "architecture": "amd64"}`))
+	mux.HandleFunc("GET /v2/hashicorp/vault/manifests/9.9.9", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	server = httptest.NewServer(mux)
+	return server
+}
+
+func TestClient_Resolve(t *testing.T) {
+	server := newTestRegistry(t)
+	defer server.Close()
+
+	client, err := registry.New(registry.WithEndpoint(releases.RegistryDockerHub, server.URL))
+	if err != nil {
+		t.Fatalf("unexpected error constructing client: %v", err)
+	}
+
+	ref := releases.ImageRef{Registry: releases.RegistryDockerHub, Repository: "hashicorp/vault", Tag: "1.15.0"}
+
+	t.Run("Index", func(t *testing.T) {
+		manifest, err := client.Resolve(context.Background(), ref)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if manifest.Digest != digestOf(testIndex) {
+			t.Fatalf("expected digest %s, got %s", digestOf(testIndex), manifest.Digest)
+		}
+		expected := []registry.Platform{
+			{OS: "linux", Architecture: "amd64"},
+			{OS: "linux", Architecture: "arm", Variant: "v7"},
+		}
+		if !reflect.DeepEqual(expected, manifest.Platforms) {
+			t.Fatalf("expected platforms %v, got %v", expected, manifest.Platforms)
+		}
+	})
+
+	t.Run("Pinned", func(t *testing.T) {
+		pinned := ref
+		pinned.Digest = digestOf(testIndex)
+
+		manifest, err := client.Resolve(context.Background(), pinned)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if manifest.Digest != pinned.Digest {
+			t.Fatalf("expected digest %s, got %s", pinned.Digest, manifest.Digest)
+		}
+	})
+
+	t.Run("Pinned SHA-512", func(t *testing.T) {
+		pinned := ref
+		pinned.Digest = sha512DigestOf(testIndex)
+
+		manifest, err := client.Resolve(context.Background(), pinned)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if manifest.Digest != pinned.Digest {
+			t.Fatalf("expected digest %s, got %s", pinned.Digest, manifest.Digest)
+		}
+	})
+
+	t.Run("Single Manifest", func(t *testing.T) {
+		single := ref
+		single.Tag = "1.14.0"
+
+		manifest, err := client.Resolve(context.Background(), single)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual([]registry.Platform{{OS: "linux", Architecture: "amd64"}}, manifest.Platforms) {
+			t.Fatalf("unexpected platforms %v", manifest.Platforms)
+		}
+	})
+
+	t.Run("Oversized", func(t *testing.T) {
+		oversized := ref
+		oversized.Tag = "oversized"
+
+		if _, err := client.Resolve(context.Background(), oversized); !errors.Is(err, registry.ErrUnexpectedResponse) {
+			t.Fatalf("expected ErrUnexpectedResponse, got: %v", err)
+		}
+	})
+
+	t.Run("No Tag Or Digest", func(t *testing.T) {
+		untagged := ref
+		untagged.Tag = ""
+
+		if _, err := client.Resolve(context.Background(), untagged); !errors.Is(err, releases.ErrInvalidImageRef) {
+			t.Fatalf("expected ErrInvalidImageRef, got: %v", err)
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		missing := ref
+		missing.Tag = "9.9.9"
+
+		if _, err := client.Resolve(context.Background(), missing); !errors.Is(err, registry.ErrManifestNotFound) {
+			t.Fatalf("expected ErrManifestNotFound, got: %v", err)
+		}
+	})
+}
@@ -2,39 +2,188 @@ package releases
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"iter"
 	"net/http"
 	"net/url"
 	"path"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 // Release returns all metadata for a specific version of a product.
-func (c *Client) Release(ctx context.Context, product string, version string) (ReleaseInfo, error) {
-	return c.singleRelease(ctx, c.makeURL(path.Join("v1", "releases", product, version), nil))
+func (c *Client) Release(ctx context.Context, product string, version string, opts ...CallOpt) (ReleaseInfo, error) {
+	effectiveOpts, err := c.newCallOpts(opts...)
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+
+	return c.singleRelease(ctx, path.Join("v1", "releases", product, version), nil, effectiveOpts)
 }
 
 // LatestRelease returns all metadata for the latest release of a product with the given
 // license class. If licenseClass is nil, the latest version of any license class is returned.
-func (c *Client) LatestRelease(ctx context.Context, product string, licenseClass *LicenseClass) (ReleaseInfo, error) {
+//
+// If the latest release has been withdrawn, a *ReleaseWithdrawnError identifying it is returned
+// instead of its metadata, unless the Client was constructed using WithAllowWithdrawn.
+func (c *Client) LatestRelease(ctx context.Context, product string, licenseClass *LicenseClass, opts ...CallOpt) (ReleaseInfo, error) {
+	effectiveOpts, err := c.newCallOpts(opts...)
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+
 	query := url.Values{}
 	if licenseClass != nil {
 		query["license_class"] = []string{string(*licenseClass)}
 	}
 
-	return c.singleRelease(ctx, c.makeURL(path.Join("v1", "releases", product, "latest"), query))
-}
-
-func (c *Client) singleRelease(ctx context.Context, url url.URL) (ReleaseInfo, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+	release, err := c.singleRelease(ctx, path.Join("v1", "releases", product, "latest"), query, effectiveOpts)
 	if err != nil {
 		return ReleaseInfo{}, err
 	}
+	if err := c.checkWithdrawn(release); err != nil {
+		return ReleaseInfo{}, err
+	}
+
+	return release, nil
+}
+
+// LatestReleases returns metadata for the latest release of each of the nominated products with
+// the given license class, keyed by product name. If licenseClass is nil, the latest version of any
+// license class is returned.
+//
+// Requests are made concurrently, subject to the limit configured using WithConcurrency. If the
+// latest release of any product cannot be retrieved, the returned map contains the results for the
+// remaining products, and the returned error joins a *ProductError for each failure.
+func (c *Client) LatestReleases(ctx context.Context, products []string, licenseClass *LicenseClass) (map[string]ReleaseInfo, error) {
+	return latestReleases(ctx, c, c.opts.concurrency, products, licenseClass)
+}
+
+// LatestReleases returns metadata for the latest release of each of the nominated products with
+// the given license class from api, as described for Client.LatestReleases. At most concurrency
+// requests are made concurrently, and values less than 1 are rejected with an error wrapping
+// ErrInvalidConcurrency.
+func LatestReleases(ctx context.Context, api ReleasesAPI, concurrency int, products []string, licenseClass *LicenseClass) (map[string]ReleaseInfo, error) {
+	if err := validateConcurrency(concurrency); err != nil {
+		return nil, err
+	}
+	return latestReleases(ctx, api, concurrency, products, licenseClass)
+}
+
+func latestReleases(ctx context.Context, api ReleasesAPI, concurrency int, products []string, licenseClass *LicenseClass) (map[string]ReleaseInfo, error) {
+	if err := validateLicenseClass(licenseClass); err != nil {
+		return nil, err
+	}
+
+	products = slices.Clone(products)
+	slices.Sort(products)
+	products = slices.Compact(products)
+	if slices.Contains(products, "") {
+		return nil, fmt.Errorf("%w: may not be empty", ErrInvalidProduct)
+	}
+
+	var mu sync.Mutex
+	results := make(map[string]ReleaseInfo, len(products))
+	errs := make([]error, 0)
+
+	forEachConcurrently(ctx, concurrency, products, func(ctx context.Context, product string) {
+		release, err := api.LatestRelease(ctx, product, licenseClass)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errs = append(errs, &ProductError{Product: product, Err: err})
+			return
+		}
+		results[product] = release
+	})
+
+	slices.SortFunc(errs, func(a, b error) int {
+		return strings.Compare(a.(*ProductError).Product, b.(*ProductError).Product)
+	})
+
+	return results, errors.Join(errs...)
+}
+
+// ReleasesByVersion returns metadata for each of the nominated versions of a product, keyed by
+// version. Duplicate versions are requested only once.
+//
+// Requests are made concurrently, subject to the limit configured using WithConcurrency. If any
+// version cannot be retrieved, the returned map contains the results for the remaining versions,
+// and the returned error is a *ReleasesByVersionError identifying the versions which do not exist
+// and any other failures.
+func (c *Client) ReleasesByVersion(ctx context.Context, product string, versions []string) (map[string]ReleaseInfo, error) {
+	return releasesByVersion(ctx, c, c.opts.concurrency, product, versions)
+}
+
+// ReleasesByVersion returns metadata for each of the nominated versions of a product from api, as
+// described for Client.ReleasesByVersion. At most concurrency requests are made concurrently, and
+// values less than 1 are rejected with an error wrapping ErrInvalidConcurrency.
+func ReleasesByVersion(ctx context.Context, api ReleasesAPI, concurrency int, product string, versions []string) (map[string]ReleaseInfo, error) {
+	if err := validateConcurrency(concurrency); err != nil {
+		return nil, err
+	}
+	return releasesByVersion(ctx, api, concurrency, product, versions)
+}
+
+func releasesByVersion(ctx context.Context, api ReleasesAPI, concurrency int, product string, versions []string) (map[string]ReleaseInfo, error) {
+	if product == "" {
+		return nil, fmt.Errorf("%w: may not be empty", ErrInvalidProduct)
+	}
 
-	resp, err := http.DefaultClient.Do(req)
+	unique := make([]string, 0, len(versions))
+	for _, version := range versions {
+		if version == "" {
+			return nil, fmt.Errorf("%w: may not be empty", ErrInvalidVersion)
+		}
+		if !slices.Contains(unique, version) {
+			unique = append(unique, version)
+		}
+	}
+
+	var mu sync.Mutex
+	results := make(map[string]ReleaseInfo, len(unique))
+	failures := make(map[string]error)
+
+	forEachConcurrently(ctx, concurrency, unique, func(ctx context.Context, version string) {
+		release, err := api.Release(ctx, product, version)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			failures[version] = err
+			return
+		}
+		results[version] = release
+	})
+
+	if len(failures) == 0 {
+		return results, nil
+	}
+
+	batchErr := &ReleasesByVersionError{
+		Product: product,
+		Errors:  make(map[string]error),
+	}
+	for _, version := range unique {
+		if err, ok := failures[version]; ok {
+			if errors.Is(err, ErrNotFound) {
+				batchErr.NotFound = append(batchErr.NotFound, version)
+			} else {
+				batchErr.Errors[version] = err
+			}
+		}
+	}
+
+	return results, batchErr
+}
+
+func (c *Client) singleRelease(ctx context.Context, pathComponents string, query url.Values, opts callOpts) (ReleaseInfo, error) {
+	resp, _, err := c.get(ctx, pathComponents, query, opts)
 	if err != nil {
 		return ReleaseInfo{}, err
 	}
@@ -42,15 +191,31 @@ func (c *Client) singleRelease(ctx context.Context, url url.URL) (ReleaseInfo, e
 		_ = resp.Body.Close()
 	}()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return ReleaseInfo{}, fmt.Errorf("%w: %w: %d", ErrInvalidStatusCode, ErrNotFound, resp.StatusCode)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return ReleaseInfo{}, fmt.Errorf("%w: %d", ErrInvalidStatusCode, resp.StatusCode)
 	}
 
+	if c.opts.strictDecoding {
+		if err := checkContentType(resp); err != nil {
+			return ReleaseInfo{}, err
+		}
+	}
+
 	var target ReleaseInfo
-	if err := json.NewDecoder(resp.Body).Decode(&target); err != nil {
+	if err := c.newDecoder(resp.Body).Decode(&target); err != nil {
 		return ReleaseInfo{}, fmt.Errorf("%w: %w", ErrInvalidResponseBody, err)
 	}
 
+	if c.opts.strictDecoding {
+		if err := target.Validate(); err != nil {
+			return ReleaseInfo{}, err
+		}
+	}
+
+	c.rewriteRelease(&target)
 	return target, nil
 }
 
@@ -59,8 +224,8 @@ func (c *Client) singleRelease(ctx context.Context, url url.URL) (ReleaseInfo, e
 // which should be guarded against in each loop iteration.
 //
 // See ExampleClient_Releases for further information on how to use the result of this function.
-func (c *Client) Releases(ctx context.Context, product string, licenseClass *LicenseClass) (iter.Seq2[ReleaseInfo, error], error) {
-	pages, err := c.ReleasesPaged(ctx, product, licenseClass)
+func (c *Client) Releases(ctx context.Context, product string, licenseClass *LicenseClass, opts ...CallOpt) (iter.Seq2[ReleaseInfo, error], error) {
+	pages, err := c.ReleasesPaged(ctx, product, licenseClass, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -85,91 +250,193 @@ func (c *Client) Releases(ctx context.Context, product string, licenseClass *Lic
 // product and license class. When ranging over the returned sequence, the second parameter may
 // be an error, which should be guarded against in each loop iteration.
 //
+// The API returns releases created strictly before the timestamp of the final release on the
+// preceding page, so releases which share a creation timestamp may straddle the boundary between
+// two pages. The releases at the end of each full page which share the timestamp of the final
+// release are therefore deferred to the start of the following page, and when every release on a
+// page shares a timestamp, the page is requested again with the largest size permitted by the API.
+// If more releases share a timestamp than fit on that page, iteration ends with an error wrapping
+// ErrTiedReleasesExceedPage. Pages may therefore contain fewer or more items than the page size
+// requested from the API.
+//
 // See ExampleClient_ReleasesPaged for further information on how to use the result of this function.
-func (c *Client) ReleasesPaged(ctx context.Context, product string, licenseClass *LicenseClass) (iter.Seq2[[]ReleaseInfo, error], error) {
+func (c *Client) ReleasesPaged(ctx context.Context, product string, licenseClass *LicenseClass, opts ...CallOpt) (iter.Seq2[[]ReleaseInfo, error], error) {
+	effectiveOpts, err := c.newCallOpts(opts...)
+	if err != nil {
+		return nil, err
+	}
+
 	if product == "" {
 		return nil, fmt.Errorf("%w: may not be empty", ErrInvalidProduct)
 	}
 
-	switch licenseClass {
-	case nil, LicenseClassAny, LicenseClassOSS, LicenseClassEnterprise, LicenseClassHCP:
-		break
-	default:
-		return nil, fmt.Errorf("%w: must be one of %s", ErrInvalidLicenseClass, licenseClassNames())
+	if err := validateLicenseClass(licenseClass); err != nil {
+		return nil, err
 	}
 
 	paginator := &releasePaginator{
-		client:         c,
-		productURL:     c.makeURL(path.Join("v1", "releases", product), nil),
-		pageSize:       16,
-		licenseClass:   licenseClass,
-		paginationMark: nil,
+		client:       c,
+		opts:         effectiveOpts,
+		productPath:  path.Join("v1", "releases", product),
+		pageSize:     releasesPageSize,
+		licenseClass: licenseClass,
 	}
 	return paginator.iterator(ctx), nil
 }
 
+// releasesPageSize is the number of releases requested from the API for each page.
+const releasesPageSize = 16
+
+// releasesMaxPageSize is the largest number of releases the API returns in a single page.
+const releasesMaxPageSize = 20
+
 type releasePaginator struct {
-	client         *Client
-	productURL     url.URL
-	pageSize       int
-	licenseClass   *LicenseClass
-	paginationMark *time.Time
+	client       *Client
+	opts         callOpts
+	productPath  string
+	pageSize     int
+	licenseClass *LicenseClass
 }
 
 func (r *releasePaginator) iterator(ctx context.Context) iter.Seq2[[]ReleaseInfo, error] {
 	return func(yield func([]ReleaseInfo, error) bool) {
+		var mark *time.Time
+		seen := make(map[string]struct{})
+
+		// Subsequent pages are requested from the endpoint which served the first, since endpoints
+		// may not be consistent with one another.
+		opts := r.opts
+
 		for {
-			page, err := r.requestPage(ctx)
+			page, endpoint, err := r.requestPage(ctx, opts, mark, r.pageSize)
 			if err != nil {
 				_ = yield(nil, err)
-				break
+				return
 			}
+			opts.endpoints = []url.URL{endpoint}
 
-			if len(page) == 0 {
-				break
+			last := len(page) < r.pageSize
+			if !last {
+				if page, last, err = r.resolveTie(ctx, opts, mark, page); err != nil {
+					_ = yield(nil, err)
+					return
+				}
 			}
-			yield(page, nil)
 
-			r.paginationMark = &page[len(page)-1].TimestampCreated
+			unseen := make([]ReleaseInfo, 0, len(page))
+			for _, item := range page {
+				if _, ok := seen[item.Version]; ok {
+					continue
+				}
+				seen[item.Version] = struct{}{}
+				unseen = append(unseen, item)
+			}
+
+			if len(unseen) > 0 && !yield(unseen, nil) {
+				return
+			}
+
+			if last {
+				return
+			}
+
+			mark = &page[len(page)-1].TimestampCreated
 		}
 	}
 }
 
-func (r *releasePaginator) requestPage(ctx context.Context) ([]ReleaseInfo, error) {
-	query := url.Values{
-		"limit": []string{strconv.Itoa(r.pageSize)},
+// resolveTie examines a full page of releases, requested using mark, for the releases at the end of
+// the page which share the creation timestamp of the final release, and which may therefore be
+// followed by further releases with that timestamp on the next page. If other releases precede
+// them, they are removed, so that the next page, which is requested from the timestamp of the final
+// release remaining, begins with them. If every release on the page shares a timestamp, the page is
+// requested again with the largest size the API permits, and the releases sharing the timestamp are
+// returned. resolveTie reports whether the page returned is the last one.
+func (r *releasePaginator) resolveTie(ctx context.Context, opts callOpts, mark *time.Time, page []ReleaseInfo) ([]ReleaseInfo, bool, error) {
+	tied := countTied(slices.Backward(page))
+	switch {
+	case tied < len(page):
+		return page[:len(page)-tied], false, nil
 	}
-	if r.paginationMark != nil {
-		query["after"] = []string{(*r.paginationMark).Format(time.RFC3339)}
+
+	page, _, err := r.requestPage(ctx, opts, mark, releasesMaxPageSize)
+	if err != nil {
+		return nil, false, err
 	}
-	if r.licenseClass != nil {
-		query["license_class"] = []string{string(*r.licenseClass)}
+
+	tied = countTied(slices.All(page))
+	switch {
+	case tied < len(page):
+		return page[:tied], false, nil
+	case len(page) < releasesMaxPageSize:
+		return page, true, nil
+	default:
+		return nil, false, fmt.Errorf("%w: more than %d releases created at %s",
+			ErrTiedReleasesExceedPage, releasesMaxPageSize, page[0].TimestampCreated.Format(time.RFC3339Nano))
 	}
+}
 
-	pageURL := r.productURL
-	pageURL.RawQuery = query.Encode()
+// countTied returns the number of leading releases of seq which share the creation timestamp of
+// the first.
+func countTied(seq iter.Seq2[int, ReleaseInfo]) int {
+	count := 0
+	var timestamp time.Time
+	for _, item := range seq {
+		if count > 0 && !item.TimestampCreated.Equal(timestamp) {
+			break
+		}
+		timestamp = item.TimestampCreated
+		count++
+	}
+	return count
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL.String(), nil)
-	if err != nil {
-		return nil, err
+func (r *releasePaginator) requestPage(ctx context.Context, opts callOpts, mark *time.Time, limit int) ([]ReleaseInfo, url.URL, error) {
+	query := url.Values{
+		"limit": []string{strconv.Itoa(limit)},
+	}
+	if mark != nil {
+		query["after"] = []string{mark.Format(time.RFC3339Nano)}
+	}
+	if r.licenseClass != nil {
+		query["license_class"] = []string{string(*r.licenseClass)}
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, endpoint, err := r.client.get(ctx, r.productPath, query, opts)
 	if err != nil {
-		return nil, err
+		return nil, url.URL{}, err
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, url.URL{}, fmt.Errorf("%w: %w: %d", ErrInvalidStatusCode, ErrNotFound, resp.StatusCode)
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrInvalidStatusCode, resp.StatusCode)
+		return nil, url.URL{}, fmt.Errorf("%w: %d", ErrInvalidStatusCode, resp.StatusCode)
+	}
+
+	if r.client.opts.strictDecoding {
+		if err := checkContentType(resp); err != nil {
+			return nil, url.URL{}, err
+		}
 	}
 
 	var target []ReleaseInfo
-	if err := json.NewDecoder(resp.Body).Decode(&target); err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrInvalidResponseBody, err)
+	if err := r.client.newDecoder(resp.Body).Decode(&target); err != nil {
+		return nil, url.URL{}, fmt.Errorf("%w: %w", ErrInvalidResponseBody, err)
 	}
 
-	return target, nil
+	if r.client.opts.strictDecoding {
+		if err := validateReleases(target); err != nil {
+			return nil, url.URL{}, err
+		}
+	}
+
+	for i := range target {
+		r.client.rewriteRelease(&target[i])
+	}
+
+	return target, endpoint, nil
 }
@@ -3,9 +3,14 @@ package releases_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -24,6 +29,88 @@ func TestClient_LatestRelease(t *testing.T) {
 	requireEqual(t, waypoint_0_11_4, release)
 }
 
+func TestClient_LatestReleases(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/releases/{product}/latest", func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		product := r.PathValue("product")
+		if product == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if licenseClass := r.URL.Query().Get("license_class"); licenseClass != "oss" {
+			w.WriteHeader(http.StatusBadRequest)
+			t.Errorf("unexpected license class: %s", licenseClass)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		requireNoError(t, json.NewEncoder(w).Encode(releases.ReleaseInfo{Name: product, Version: "1.0.0"}))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL), releases.WithConcurrency(2))
+	requireNoError(t, err)
+
+	products := []string{"consul", "missing", "nomad", "packer", "terraform", "vault", "consul"}
+	latest, err := client.LatestReleases(context.Background(), products, releases.LicenseClassOSS)
+
+	var productErr *releases.ProductError
+	if !errors.As(err, &productErr) {
+		t.Fatalf("expected a *ProductError, got: %v", err)
+	}
+	requireEqual(t, "missing", productErr.Product)
+	if !errors.Is(err, releases.ErrInvalidStatusCode) {
+		t.Fatalf("expected error to wrap ErrInvalidStatusCode, got: %v", err)
+	}
+
+	requireEqual(t, 5, len(latest))
+	for _, product := range []string{"consul", "nomad", "packer", "terraform", "vault"} {
+		requireEqual(t, product, latest[product].Name)
+	}
+
+	if maxInFlight.Load() > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, observed %d", maxInFlight.Load())
+	}
+}
+
+func TestLatestReleases(t *testing.T) {
+	snapshot := releases.NewSnapshot(
+		releases.ReleaseInfo{Name: "consul", Version: "1.0.0"},
+		releases.ReleaseInfo{Name: "vault", Version: "1.0.0"},
+	)
+
+	_, err := releases.LatestReleases(context.Background(), snapshot, 0, []string{"consul"}, nil)
+	if !errors.Is(err, releases.ErrInvalidConcurrency) {
+		t.Fatalf("expected ErrInvalidConcurrency, got: %v", err)
+	}
+
+	latest, err := releases.LatestReleases(context.Background(), snapshot, 2, []string{"consul", "missing", "vault"}, nil)
+
+	var productErr *releases.ProductError
+	if !errors.As(err, &productErr) {
+		t.Fatalf("expected a *ProductError, got: %v", err)
+	}
+	requireEqual(t, "missing", productErr.Product)
+	if !errors.Is(err, releases.ErrNotFound) {
+		t.Fatalf("expected error to wrap ErrNotFound, got: %v", err)
+	}
+
+	requireEqual(t, 2, len(latest))
+	requireEqual(t, "vault", latest["vault"].Name)
+}
+
 func TestClient_Release(t *testing.T) {
 	server := httptest.NewServer(makeTestReleasesHandler(t))
 
@@ -43,6 +130,88 @@ func TestClient_Release(t *testing.T) {
 	})
 }
 
+func TestClient_ReleasesByVersion(t *testing.T) {
+	var requests sync.Map
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/releases/waypoint/{version}", func(w http.ResponseWriter, r *http.Request) {
+		version := r.PathValue("version")
+		if _, loaded := requests.LoadOrStore(version, true); loaded {
+			t.Errorf("version %s requested more than once", version)
+		}
+
+		switch version {
+		case "0.1.0":
+			w.WriteHeader(http.StatusOK)
+			requireNoError(t, json.NewEncoder(w).Encode(waypoint_0_1_0))
+		case "0.11.4":
+			w.WriteHeader(http.StatusOK)
+			requireNoError(t, json.NewEncoder(w).Encode(waypoint_0_11_4))
+		case "0.5.0":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL))
+	requireNoError(t, err)
+
+	t.Run("All Found", func(t *testing.T) {
+		found, err := client.ReleasesByVersion(context.Background(), "waypoint", []string{"0.1.0", "0.11.4"})
+		requireNoError(t, err)
+		requireEqual(t, map[string]releases.ReleaseInfo{"0.1.0": waypoint_0_1_0, "0.11.4": waypoint_0_11_4}, found)
+	})
+
+	t.Run("Partial Failure", func(t *testing.T) {
+		requests.Clear()
+
+		versions := []string{"9.9.9", "0.1.0", "0.5.0", "0.1.0", "1.0.0"}
+		found, err := client.ReleasesByVersion(context.Background(), "waypoint", versions)
+		requireEqual(t, map[string]releases.ReleaseInfo{"0.1.0": waypoint_0_1_0}, found)
+
+		var batchErr *releases.ReleasesByVersionError
+		if !errors.As(err, &batchErr) {
+			t.Fatalf("expected a *ReleasesByVersionError, got: %v", err)
+		}
+		requireEqual(t, "waypoint", batchErr.Product)
+		requireEqual(t, []string{"9.9.9", "1.0.0"}, batchErr.NotFound)
+		requireEqual(t, 1, len(batchErr.Errors))
+		if !errors.Is(batchErr.Errors["0.5.0"], releases.ErrInvalidStatusCode) {
+			t.Fatalf("expected 0.5.0 to fail with ErrInvalidStatusCode, got: %v", batchErr.Errors["0.5.0"])
+		}
+		if !errors.Is(err, releases.ErrNotFound) {
+			t.Fatalf("expected error to wrap ErrNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("Empty Version", func(t *testing.T) {
+		_, err := client.ReleasesByVersion(context.Background(), "waypoint", []string{"0.1.0", ""})
+		if !errors.Is(err, releases.ErrInvalidVersion) {
+			t.Fatalf("expected ErrInvalidVersion, got: %v", err)
+		}
+	})
+}
+
+func TestReleasesByVersion(t *testing.T) {
+	snapshot := releases.NewSnapshot(waypoint_0_1_0, waypoint_0_11_4)
+
+	_, err := releases.ReleasesByVersion(context.Background(), snapshot, 0, "waypoint", []string{"0.1.0"})
+	if !errors.Is(err, releases.ErrInvalidConcurrency) {
+		t.Fatalf("expected ErrInvalidConcurrency, got: %v", err)
+	}
+
+	found, err := releases.ReleasesByVersion(context.Background(), snapshot, 1, "waypoint", []string{"0.1.0", "9.9.9", "0.11.4"})
+	requireEqual(t, map[string]releases.ReleaseInfo{"0.1.0": waypoint_0_1_0, "0.11.4": waypoint_0_11_4}, found)
+
+	var batchErr *releases.ReleasesByVersionError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected a *ReleasesByVersionError, got: %v", err)
+	}
+	requireEqual(t, []string{"9.9.9"}, batchErr.NotFound)
+}
+
 func TestClient_Releases(t *testing.T) {
 	server := httptest.NewServer(makeTestReleasesHandler(t))
 
@@ -70,20 +239,133 @@ func TestClient_ReleasesPaged(t *testing.T) {
 	pages := collectResults(t, releasePagesIterator)
 	requireEqual(t, 3, len(pages))
 	requireEqual(t, waypoint_0_11_4, pages[0][0])
-	requireEqual(t, waypoint_0_1_0, pages[2][10])
+	requireEqual(t, waypoint_0_1_0, pages[2][12])
 }
 
-func makeTestReleasesHandler(t *testing.T) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		mustWriteFile := func(path string) {
-			w.WriteHeader(http.StatusOK)
-			data, err := os.ReadFile(path)
+func TestClient_ReleasesPaged_EqualTimestamps(t *testing.T) {
+	testCases := []struct {
+		name    string
+		groups  []int
+		wantErr bool
+	}{
+		{name: "No Ties", groups: repeatInt(1, 40)},
+		{name: "Tie Across Boundary", groups: []int{14, 3, 14, 2, 6}},
+		{name: "Single Tie At Boundary", groups: []int{15, 3, 10}},
+		{name: "Tie Filling Full Page", groups: []int{10, 16, 14}},
+		{name: "Tie Exceeding Full Page", groups: []int{1, 19, 10}},
+		{name: "Tie Ending Releases", groups: []int{2, 18}},
+		{name: "Tie Exceeding Largest Page", groups: []int{1, 20, 10}, wantErr: true},
+		{name: "Everything Tied", groups: []int{40}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fixtures := makeTiedReleases(tc.groups)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				serveTestReleasesPage(t, w, r, fixtures)
+			}))
+			defer server.Close()
+
+			client, err := releases.New(releases.WithBaseURL(server.URL))
 			requireNoError(t, err)
-			n, err := w.Write(data)
+
+			releasesIterator, err := client.Releases(context.Background(), "tied", nil)
 			requireNoError(t, err)
-			requireEqual(t, len(data), n)
+
+			if tc.wantErr {
+				var err error
+				for _, err = range releasesIterator {
+					if err != nil {
+						break
+					}
+				}
+				if !errors.Is(err, releases.ErrTiedReleasesExceedPage) {
+					t.Fatalf("expected ErrTiedReleasesExceedPage, got: %v", err)
+				}
+				return
+			}
+
+			requireEqual(t, versionsOf(fixtures), versionsOf(collectResults(t, releasesIterator)))
+		})
+	}
+}
+
+func TestClient_ReleasesPaged_SubSecondPrecision(t *testing.T) {
+	base := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	var fixtures []releases.ReleaseInfo
+	for i := range 20 {
+		fixtures = append(fixtures, releases.ReleaseInfo{
+			Name:             "precise",
+			Version:          fmt.Sprintf("1.0.%d", 19-i),
+			TimestampCreated: base.Add(-time.Duration(i) * time.Millisecond),
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveTestReleasesPage(t, w, r, fixtures)
+	}))
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL))
+	requireNoError(t, err)
+
+	releasesIterator, err := client.Releases(context.Background(), "precise", nil)
+	requireNoError(t, err)
+
+	requireEqual(t, 20, len(collectResults(t, releasesIterator)))
+}
+
+func TestClient_ReleasesPaged_EarlyExit(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		serveTestReleasesPage(t, w, r, waypointReleases)
+	}))
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL))
+	requireNoError(t, err)
+
+	t.Run("Pages", func(t *testing.T) {
+		requests.Store(0)
+
+		releasePagesIterator, err := client.ReleasesPaged(context.Background(), "waypoint", releases.LicenseClassOSS)
+		requireNoError(t, err)
+
+		for _, err := range releasePagesIterator {
+			requireNoError(t, err)
+			break
 		}
+		requireEqual(t, int32(1), requests.Load())
+	})
+
+	t.Run("Releases", func(t *testing.T) {
+		requests.Store(0)
+
+		releasesIterator, err := client.Releases(context.Background(), "waypoint", releases.LicenseClassOSS)
+		requireNoError(t, err)
 
+		for release, err := range releasesIterator {
+			requireNoError(t, err)
+			if release.Version == "0.8.1" {
+				break
+			}
+		}
+		requireEqual(t, int32(1), requests.Load())
+	})
+
+	t.Run("Reuse", func(t *testing.T) {
+		releasePagesIterator, err := client.ReleasesPaged(context.Background(), "waypoint", releases.LicenseClassOSS)
+		requireNoError(t, err)
+
+		first := collectResults(t, releasePagesIterator)
+		second := collectResults(t, releasePagesIterator)
+		requireEqual(t, first, second)
+	})
+}
+
+func makeTestReleasesHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		mustWriteJSON := func(val any) {
 			w.WriteHeader(http.StatusOK)
 			enc := json.NewEncoder(w)
@@ -92,30 +374,7 @@ func makeTestReleasesHandler(t *testing.T) http.Handler {
 
 		switch r.URL.Path {
 		case "/v1/releases/waypoint":
-			if r.URL.Path != "/v1/releases/waypoint" {
-				w.WriteHeader(http.StatusNotFound)
-				t.Errorf("unexpected path accessed: %s", r.URL.Path)
-			}
-
-			if limit := r.URL.Query().Get("limit"); limit != "16" {
-				w.WriteHeader(http.StatusBadRequest)
-				t.Errorf("unexpected limit set: %s", limit)
-			}
-
-			after := r.URL.Query().Get("after")
-			switch after {
-			case "":
-				mustWriteFile("testdata/releases/page1.json")
-			case "2022-04-07T16:15:06Z":
-				mustWriteFile("testdata/releases/page2.json")
-			case "2021-04-08T18:56:58Z":
-				mustWriteFile("testdata/releases/page3.json")
-			case "2020-10-15T16:37:48Z":
-				mustWriteFile("testdata/releases/page4.json")
-			default:
-				w.WriteHeader(http.StatusBadRequest)
-				t.Errorf("unexpected after value: %s", after)
-			}
+			serveTestReleasesPage(t, w, r, waypointReleases)
 		case "/v1/releases/waypoint/0.1.0":
 			mustWriteJSON(waypoint_0_1_0)
 		case "/v1/releases/waypoint/0.11.4":
@@ -129,6 +388,92 @@ func makeTestReleasesHandler(t *testing.T) http.Handler {
 	})
 }
 
+// serveTestReleasesPage emulates the pagination semantics of the releases API over a slice of
+// releases sorted in descending order of creation: at most "limit" releases created strictly
+// before "after" are returned.
+func serveTestReleasesPage(t *testing.T, w http.ResponseWriter, r *http.Request, items []releases.ReleaseInfo) {
+	t.Helper()
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || (limit != 16 && limit != 20) {
+		w.WriteHeader(http.StatusBadRequest)
+		t.Errorf("unexpected limit set: %s", r.URL.Query().Get("limit"))
+		return
+	}
+
+	page := make([]releases.ReleaseInfo, 0, limit)
+	after := r.URL.Query().Get("after")
+	for _, item := range items {
+		if after != "" {
+			mark, err := time.Parse(time.RFC3339Nano, after)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				t.Errorf("unexpected after value: %s", after)
+				return
+			}
+			if !item.TimestampCreated.Before(mark) {
+				continue
+			}
+		}
+		if len(page) == limit {
+			break
+		}
+		page = append(page, item)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	requireNoError(t, json.NewEncoder(w).Encode(page))
+}
+
+// makeTiedReleases returns releases in descending order of creation, in which each element of
+// groups gives the number of consecutive releases sharing a creation timestamp.
+func makeTiedReleases(groups []int) []releases.ReleaseInfo {
+	var result []releases.ReleaseInfo
+
+	timestamp := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for groupIdx, size := range groups {
+		for i := range size {
+			result = append(result, releases.ReleaseInfo{
+				Name:             "tied",
+				Version:          fmt.Sprintf("%d.%d.0", len(groups)-groupIdx, i),
+				TimestampCreated: timestamp,
+			})
+		}
+		timestamp = timestamp.Add(-time.Hour)
+	}
+
+	return result
+}
+
+func repeatInt(value int, count int) []int {
+	result := make([]int, count)
+	for i := range result {
+		result[i] = value
+	}
+	return result
+}
+
+var waypointReleases = func() []releases.ReleaseInfo {
+	var result []releases.ReleaseInfo
+	for _, path := range []string{
+		"testdata/releases/page1.json",
+		"testdata/releases/page2.json",
+		"testdata/releases/page3.json",
+	} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			panic(err)
+		}
+
+		var page []releases.ReleaseInfo
+		if err := json.Unmarshal(data, &page); err != nil {
+			panic(err)
+		}
+		result = append(result, page...)
+	}
+	return result
+}()
+
 var waypoint_0_11_4 = releases.ReleaseInfo{Builds: []releases.BuildInfo{
 	{
 		Arch:        "amd64",
@@ -184,8 +529,9 @@ var waypoint_0_11_4 = releases.ReleaseInfo{Builds: []releases.BuildInfo{
 	LicenseClass:  "oss",
 	Name:          "waypoint",
 	Status: releases.ReleaseStatus{
-		Message: "",
-		State:   "supported",
+		Message:          "",
+		State:            "supported",
+		TimestampUpdated: time.Date(2023, time.August, 9, 18, 33, 15, 901000000, time.UTC),
 	},
 	TimestampCreated:           time.Date(2023, time.August, 9, 18, 33, 15, 901000000, time.UTC),
 	TimestampUpdated:           time.Date(2023, time.August, 9, 18, 33, 15, 901000000, time.UTC),
@@ -249,8 +595,9 @@ var waypoint_0_1_0 = releases.ReleaseInfo{
 	LicenseClass:  "oss",
 	Name:          "waypoint",
 	Status: releases.ReleaseStatus{
-		Message: "",
-		State:   "supported",
+		Message:          "",
+		State:            "supported",
+		TimestampUpdated: time.Date(2020, time.October, 15, 16, 37, 48, 0, time.UTC),
 	},
 	TimestampCreated:           time.Date(2020, time.October, 15, 16, 37, 48, 0, time.UTC),
 	TimestampUpdated:           time.Date(2020, time.October, 15, 16, 37, 48, 0, time.UTC),
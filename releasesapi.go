@@ -0,0 +1,81 @@
+package releases
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ReleasesAPI is the set of operations of the Releases API which are provided by Client. Code which
+// depends on ReleasesAPI rather than Client may be used with fakes, with alternative backends such
+// as a Snapshot, or with decorators such as CachingAPI and LoggingAPI.
+//
+// Implementations should honor the CallOpt options they are passed where applicable, and must
+// otherwise ignore them, other than returning any error produced by applying them.
+type ReleasesAPI interface {
+	// Products returns the names of all products tracked by the API.
+	Products(ctx context.Context, opts ...CallOpt) ([]string, error)
+
+	// Release returns all metadata for a specific version of a product.
+	Release(ctx context.Context, product string, version string, opts ...CallOpt) (ReleaseInfo, error)
+
+	// LatestRelease returns all metadata for the latest release of a product with the given
+	// license class, or of any license class if licenseClass is nil.
+	LatestRelease(ctx context.Context, product string, licenseClass *LicenseClass, opts ...CallOpt) (ReleaseInfo, error)
+
+	// Releases returns an iterator over the releases of a product with the given license class,
+	// newest first.
+	Releases(ctx context.Context, product string, licenseClass *LicenseClass, opts ...CallOpt) (iter.Seq2[ReleaseInfo, error], error)
+
+	// ReleasesPaged returns an iterator over pages of the releases of a product with the given
+	// license class, newest first.
+	ReleasesPaged(ctx context.Context, product string, licenseClass *LicenseClass, opts ...CallOpt) (iter.Seq2[[]ReleaseInfo, error], error)
+}
+
+var _ ReleasesAPI = (*Client)(nil)
+
+// applyCallOpts applies opts without reference to a Client, for use by implementations of
+// ReleasesAPI which need to inspect them, such as CachingAPI.
+func applyCallOpts(opts []CallOpt) (callOpts, error) {
+	effectiveOpts := callOpts{
+		header: http.Header{},
+	}
+	for _, opt := range opts {
+		if err := opt(&effectiveOpts); err != nil {
+			return callOpts{}, err
+		}
+	}
+	return effectiveOpts, nil
+}
+
+// cacheKey returns a key identifying the endpoint to which a call with the given options is made,
+// for use by CachingAPI. It is empty unless the endpoint was overridden using WithCallBaseURL.
+func (o callOpts) cacheKey() string {
+	keys := make([]string, len(o.endpoints))
+	for i, endpoint := range o.endpoints {
+		keys[i] = endpoint.String()
+	}
+	return strings.Join(keys, " ")
+}
+
+// cloneRelease returns a copy of release which shares no mutable state with it, so that read-only
+// implementations of ReleasesAPI may return releases without exposing their own copies.
+func cloneRelease(release ReleaseInfo) ReleaseInfo {
+	release.Builds = slices.Clone(release.Builds)
+	release.URLSHASUMsSignatures = slices.Clone(release.URLSHASUMsSignatures)
+	return release
+}
+
+func cloneReleases(items []ReleaseInfo) []ReleaseInfo {
+	if items == nil {
+		return nil
+	}
+
+	result := make([]ReleaseInfo, len(items))
+	for i, item := range items {
+		result[i] = cloneRelease(item)
+	}
+	return result
+}
@@ -0,0 +1,333 @@
+package releasestest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Fault describes how a FaultTransport misbehaves when serving a request. The zero value serves
+// the request normally. Fields may be combined, for example to delay an error response.
+type Fault struct {
+	// Latency delays the response. If the context of the request is cancelled while waiting, the
+	// request fails with the error of the context.
+	Latency time.Duration
+
+	// Reset causes the request to fail with a connection reset error, without being sent.
+	Reset bool
+
+	// StatusCode, if non-zero, causes the request to be answered with an error response with the
+	// given status code, without being sent.
+	StatusCode int
+
+	// RetryAfter sets the Retry-After header of an error response produced using StatusCode.
+	RetryAfter string
+
+	// ContentType replaces the Content-Type header of the response.
+	ContentType string
+
+	// Truncate causes only the first half of the body of the response to be returned.
+	Truncate bool
+}
+
+// Latency returns a Fault which delays the response by d.
+func Latency(d time.Duration) Fault {
+	return Fault{Latency: d}
+}
+
+// TooManyRequests returns a Fault which answers with "429 Too Many Requests" and the given
+// Retry-After header, which is omitted if empty.
+func TooManyRequests(retryAfter string) Fault {
+	return Fault{StatusCode: http.StatusTooManyRequests, RetryAfter: retryAfter}
+}
+
+// ServerError returns a Fault which answers with the given 5xx status code.
+func ServerError(statusCode int) Fault {
+	return Fault{StatusCode: statusCode}
+}
+
+// TruncatedBody returns a Fault which cuts the body of the response short, so that JSON responses
+// cannot be decoded.
+func TruncatedBody() Fault {
+	return Fault{Truncate: true}
+}
+
+// WrongContentType returns a Fault which replaces the Content-Type of the response with
+// contentType, for example "text/html" as served by a misconfigured proxy.
+func WrongContentType(contentType string) Fault {
+	return Fault{ContentType: contentType}
+}
+
+// ConnectionReset returns a Fault which fails the request with a connection reset error.
+func ConnectionReset() Fault {
+	return Fault{Reset: true}
+}
+
+// Repeat returns a slice containing fault n times, for use in a FaultRule, for example to inject a
+// burst of server errors.
+func Repeat(n int, fault Fault) []Fault {
+	faults := make([]Fault, n)
+	for i := range faults {
+		faults[i] = fault
+	}
+	return faults
+}
+
+// FaultRequest is a request considered by a FaultTransport, with its position within a pagination
+// sequence.
+type FaultRequest struct {
+	*http.Request
+
+	// Page is the 1-based number of the page of releases requested, or 0 if the request is not
+	// for a page of releases. Retries of a page have the same number as the original request.
+	Page int
+}
+
+// Matcher selects the requests to which a FaultRule applies.
+type Matcher func(req FaultRequest) bool
+
+// AnyRequest returns a Matcher which matches every request.
+func AnyRequest() Matcher {
+	return func(FaultRequest) bool {
+		return true
+	}
+}
+
+// PathPrefix returns a Matcher which matches requests whose URL path begins with prefix.
+func PathPrefix(prefix string) Matcher {
+	return func(req FaultRequest) bool {
+		return strings.HasPrefix(req.URL.Path, prefix)
+	}
+}
+
+// OnPage returns a Matcher which matches requests for the given page of releases of any product.
+func OnPage(page int) Matcher {
+	return func(req FaultRequest) bool {
+		return req.Page == page
+	}
+}
+
+// FaultRule injects faults into requests selected by Match. Each matching request consumes the
+// next of Faults, in order, and once they are exhausted the rule no longer applies. For example,
+// the following rule causes the second page of releases to fail twice before succeeding:
+//
+//	releasestest.FaultRule{
+//		Match:  releasestest.OnPage(2),
+//		Faults: releasestest.Repeat(2, releasestest.ServerError(http.StatusServiceUnavailable)),
+//	}
+type FaultRule struct {
+	// Match selects the requests to which the rule applies. If nil, the rule applies to every
+	// request.
+	Match Matcher
+
+	// Faults are injected into successive matching requests.
+	Faults []Fault
+}
+
+// FaultTransport is an http.RoundTripper which injects faults into requests according to a
+// deterministic scenario described by a sequence of FaultRule, and otherwise delegates to a base
+// transport. For each request, the first rule which matches and has faults remaining applies. It
+// may be used with a Server as follows:
+//
+//	transport := releasestest.NewFaultTransport(server.Client().Transport, rules...)
+//	client, err := server.NewClient(releases.WithHTTPClient(&http.Client{Transport: transport}))
+type FaultTransport struct {
+	base http.RoundTripper
+
+	mu       sync.Mutex
+	rules    []FaultRule
+	pages    map[string]pagePosition
+	requests int
+}
+
+type pagePosition struct {
+	after string
+	page  int
+}
+
+// NewFaultTransport returns a FaultTransport which applies rules to requests, delegating to base.
+// If base is nil, http.DefaultTransport is used.
+func NewFaultTransport(base http.RoundTripper, rules ...FaultRule) *FaultTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	cloned := make([]FaultRule, len(rules))
+	for i, rule := range rules {
+		cloned[i] = FaultRule{Match: rule.Match, Faults: append([]Fault(nil), rule.Faults...)}
+	}
+
+	return &FaultTransport{
+		base:  base,
+		rules: cloned,
+		pages: make(map[string]pagePosition),
+	}
+}
+
+// Requests returns the number of requests made using the transport, including those answered by
+// faults.
+func (t *FaultTransport) Requests() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.requests
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fault := t.nextFault(req)
+
+	if fault.Latency > 0 {
+		if err := sleep(req.Context(), fault.Latency); err != nil {
+			closeRequestBody(req)
+			return nil, err
+		}
+	}
+
+	if fault.Reset {
+		closeRequestBody(req)
+		return nil, &net.OpError{
+			Op:   "read",
+			Net:  "tcp",
+			Addr: fakeAddr(req.URL.Host),
+			Err:  os.NewSyscallError("read", syscall.ECONNRESET),
+		}
+	}
+
+	var resp *http.Response
+	if fault.StatusCode != 0 {
+		closeRequestBody(req)
+		resp = errorResponse(req, fault.StatusCode)
+		if fault.RetryAfter != "" {
+			resp.Header.Set("Retry-After", fault.RetryAfter)
+		}
+	} else {
+		var err error
+		if resp, err = t.base.RoundTrip(req); err != nil {
+			return nil, err
+		}
+	}
+
+	if fault.ContentType != "" {
+		resp.Header.Set("Content-Type", fault.ContentType)
+	}
+
+	if fault.Truncate {
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = body[:len(body)/2]
+
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		resp.ContentLength = int64(len(body))
+		resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+
+	return resp, nil
+}
+
+// nextFault records req, and returns the fault to inject into it.
+func (t *FaultTransport) nextFault(req *http.Request) Fault {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.requests++
+	faultReq := FaultRequest{Request: req, Page: t.page(req)}
+
+	for i, rule := range t.rules {
+		if len(rule.Faults) == 0 || (rule.Match != nil && !rule.Match(faultReq)) {
+			continue
+		}
+		t.rules[i].Faults = rule.Faults[1:]
+		return rule.Faults[0]
+	}
+	return Fault{}
+}
+
+// page returns the number of the page of releases requested by req, or 0 if it is not a request
+// for releases. A request without an "after" parameter starts a new sequence, and a request with
+// a different "after" parameter from the previous request of the sequence advances it.
+func (t *FaultTransport) page(req *http.Request) int {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(segments) < 3 || segments[len(segments)-3] != "v1" || segments[len(segments)-2] != "releases" {
+		return 0
+	}
+
+	// The sequence is identified by product and license class alone, since a page may be requested
+	// again with a different limit, for example to resolve releases sharing a timestamp.
+	query := req.URL.Query()
+	after := query.Get("after")
+	key := req.URL.Host + req.URL.Path + "?" + url.Values{"license_class": query["license_class"]}.Encode()
+
+	position, ok := t.pages[key]
+	switch {
+	case after == "" || !ok:
+		position = pagePosition{page: 1}
+	case after != position.after:
+		position.page++
+	}
+	position.after = after
+	t.pages[key] = position
+
+	return position.page
+}
+
+func errorResponse(req *http.Request, statusCode int) *http.Response {
+	body := fmt.Sprintf(`{"code":%d,"message":%q}`, statusCode, http.StatusText(statusCode))
+
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode: statusCode,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"Content-Type":   {MediaType},
+			"Content-Length": {strconv.Itoa(len(body))},
+		},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
+
+// fakeAddr is the remote address reported by connection reset errors.
+type fakeAddr string
+
+func (a fakeAddr) Network() string {
+	return "tcp"
+}
+
+func (a fakeAddr) String() string {
+	return string(a)
+}
@@ -0,0 +1,195 @@
+package releasestest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+	"github.com/jen20/go-hashicorp-releases-client/releasestest"
+)
+
+// newFaultyClient returns a server with 40 releases of terraform, and a client whose requests to it
+// are subject to rules.
+func newFaultyClient(t *testing.T, rules []releasestest.FaultRule, opts ...releases.ClientOpt) (*releasestest.FaultTransport, *releases.Client) {
+	t.Helper()
+
+	server := releasestest.NewServer()
+	t.Cleanup(server.Close)
+
+	for i := range 40 {
+		server.Add(releasestest.NewRelease("terraform", fmt.Sprintf("1.%d.0", i)).Build())
+	}
+
+	transport := releasestest.NewFaultTransport(server.Client().Transport, rules...)
+	client, err := server.NewClient(append(opts, releases.WithHTTPClient(&http.Client{Transport: transport}))...)
+	requireNoError(t, err)
+
+	return transport, client
+}
+
+func TestFaultTransport_PageFailsThenSucceeds(t *testing.T) {
+	transport, client := newFaultyClient(t, []releasestest.FaultRule{
+		{
+			Match:  releasestest.OnPage(2),
+			Faults: releasestest.Repeat(2, releasestest.ServerError(http.StatusServiceUnavailable)),
+		},
+	}, releases.WithRetries(2))
+
+	items, err := client.Releases(context.Background(), "terraform", nil)
+	requireNoError(t, err)
+
+	count := 0
+	for _, err := range items {
+		requireNoError(t, err)
+		count++
+	}
+	requireEqual(t, 40, count)
+
+	// Three pages, the second of which was requested three times.
+	requireEqual(t, 5, transport.Requests())
+}
+
+func TestFaultTransport_MidPaginationFailure(t *testing.T) {
+	_, client := newFaultyClient(t, []releasestest.FaultRule{
+		{
+			Match:  releasestest.OnPage(2),
+			Faults: releasestest.Repeat(3, releasestest.ServerError(http.StatusInternalServerError)),
+		},
+	}, releases.WithRetries(2))
+
+	pages, err := client.ReleasesPaged(context.Background(), "terraform", nil)
+	requireNoError(t, err)
+
+	var sizes []int
+	var lastErr error
+	for page, err := range pages {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		sizes = append(sizes, len(page))
+	}
+
+	requireEqual(t, []int{15}, sizes)
+	if !errors.Is(lastErr, releases.ErrInvalidStatusCode) {
+		t.Fatalf("expected ErrInvalidStatusCode, got: %v", lastErr)
+	}
+}
+
+func TestFaultTransport_TooManyRequests(t *testing.T) {
+	transport, client := newFaultyClient(t, []releasestest.FaultRule{
+		{Faults: []releasestest.Fault{releasestest.TooManyRequests("0")}},
+	}, releases.WithRetries(1))
+
+	release, err := client.LatestRelease(context.Background(), "terraform", nil)
+	requireNoError(t, err)
+	requireEqual(t, "1.39.0", release.Version)
+	requireEqual(t, 2, transport.Requests())
+}
+
+func TestFaultTransport_ServerErrorBurst(t *testing.T) {
+	transport, client := newFaultyClient(t, []releasestest.FaultRule{
+		{Faults: releasestest.Repeat(3, releasestest.ServerError(http.StatusBadGateway))},
+	}, releases.WithRetries(2))
+
+	_, err := client.LatestRelease(context.Background(), "terraform", nil)
+	if !errors.Is(err, releases.ErrInvalidStatusCode) {
+		t.Fatalf("expected ErrInvalidStatusCode, got: %v", err)
+	}
+	requireEqual(t, 3, transport.Requests())
+}
+
+func TestFaultTransport_ConnectionReset(t *testing.T) {
+	_, client := newFaultyClient(t, []releasestest.FaultRule{
+		{Faults: []releasestest.Fault{releasestest.ConnectionReset()}},
+	})
+
+	_, err := client.LatestRelease(context.Background(), "terraform", nil)
+	if !errors.Is(err, syscall.ECONNRESET) {
+		t.Fatalf("expected ECONNRESET, got: %v", err)
+	}
+
+	// The fault has been consumed, so the next request succeeds.
+	_, err = client.LatestRelease(context.Background(), "terraform", nil)
+	requireNoError(t, err)
+}
+
+func TestFaultTransport_TruncatedBody(t *testing.T) {
+	transport, client := newFaultyClient(t, []releasestest.FaultRule{
+		{Faults: []releasestest.Fault{releasestest.TruncatedBody()}},
+	}, releases.WithRetries(2))
+
+	_, err := client.Release(context.Background(), "terraform", "1.0.0")
+	if !errors.Is(err, releases.ErrInvalidResponseBody) {
+		t.Fatalf("expected ErrInvalidResponseBody, got: %v", err)
+	}
+	requireEqual(t, 1, transport.Requests())
+}
+
+func TestFaultTransport_WrongContentType(t *testing.T) {
+	_, client := newFaultyClient(t, []releasestest.FaultRule{
+		{
+			Match:  releasestest.PathPrefix("/v1/products"),
+			Faults: []releasestest.Fault{releasestest.WrongContentType("text/html")},
+		},
+	}, releases.WithStrictDecoding())
+
+	_, err := client.Products(context.Background())
+	if !errors.Is(err, releases.ErrInvalidResponseContentType) {
+		t.Fatalf("expected ErrInvalidResponseContentType, got: %v", err)
+	}
+}
+
+func TestFaultTransport_Latency(t *testing.T) {
+	_, client := newFaultyClient(t, []releasestest.FaultRule{
+		{Faults: []releasestest.Fault{releasestest.Latency(time.Minute)}},
+	})
+
+	_, err := client.LatestRelease(context.Background(), "terraform", nil, releases.WithCallTimeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+	}
+}
+
+func TestFaultTransport_PageRequestedWithLargerLimit(t *testing.T) {
+	server := releasestest.NewServer()
+	t.Cleanup(server.Close)
+
+	// The second page consists entirely of releases sharing a timestamp, so it is requested again
+	// with a larger limit.
+	created := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 40 {
+		timestamp := created.Add(-time.Duration(i) * time.Hour)
+		if i >= 15 && i < 32 {
+			timestamp = created.Add(-15 * time.Hour)
+		}
+		server.Add(releasestest.NewRelease("terraform", fmt.Sprintf("1.%d.0", i)).CreatedAt(timestamp).Build())
+	}
+
+	var pages []int
+	transport := releasestest.NewFaultTransport(server.Client().Transport, releasestest.FaultRule{
+		Match: func(req releasestest.FaultRequest) bool {
+			pages = append(pages, req.Page)
+			return false
+		},
+		Faults: []releasestest.Fault{{}},
+	})
+	client, err := server.NewClient(releases.WithHTTPClient(&http.Client{Transport: transport}))
+	requireNoError(t, err)
+
+	items, err := client.Releases(context.Background(), "terraform", nil)
+	requireNoError(t, err)
+
+	count := 0
+	for _, err := range items {
+		requireNoError(t, err)
+		count++
+	}
+	requireEqual(t, 40, count)
+	requireEqual(t, []int{1, 2, 2, 3}, pages)
+}
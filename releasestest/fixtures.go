@@ -0,0 +1,132 @@
+package releasestest
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+// DownloadBaseURL is the URL under which the artifacts of releases built using ReleaseBuilder are
+// located, unless they are served by a Server constructed using WithArtifacts.
+const DownloadBaseURL = "https://releases.hashicorp.com"
+
+// SigningKeyID is the key ID which appears in the filenames of the signatures of releases built
+// using ReleaseBuilder.
+const SigningKeyID = "72D7468F"
+
+// ReleaseBuilder builds release metadata fixtures. A builder is obtained using NewRelease, and
+// its methods may be chained, for example:
+//
+//	release := releasestest.NewRelease("vault", "1.15.0").
+//		WithLicenseClass(releases.LicenseClassEnterprise).
+//		WithBuild("linux", "amd64").
+//		CreatedAt(time.Date(2023, time.September, 27, 15, 0, 0, 0, time.UTC)).
+//		Build()
+type ReleaseBuilder struct {
+	release releases.ReleaseInfo
+}
+
+// NewRelease returns a builder for a supported OSS release of version of product, with no builds.
+func NewRelease(product string, version string) *ReleaseBuilder {
+	return &ReleaseBuilder{
+		release: releases.ReleaseInfo{
+			Name:                product,
+			Version:             version,
+			LicenseClass:        *releases.LicenseClassOSS,
+			Status:              releases.ReleaseStatus{State: releases.ReleaseStateSupported},
+			URLLicense:          fmt.Sprintf("https://github.com/hashicorp/%s/blob/main/LICENSE", product),
+			URLProjectWebsite:   fmt.Sprintf("https://www.hashicorp.com/products/%s", product),
+			URLSourceRepository: fmt.Sprintf("https://github.com/hashicorp/%s", product),
+		},
+	}
+}
+
+// WithLicenseClass sets the license class of the release.
+func (b *ReleaseBuilder) WithLicenseClass(licenseClass *releases.LicenseClass) *ReleaseBuilder {
+	b.release.LicenseClass = *licenseClass
+	return b
+}
+
+// WithBuild adds a build of the release for the given operating system and architecture, which
+// is downloaded as a zip archive.
+func (b *ReleaseBuilder) WithBuild(os string, arch string) *ReleaseBuilder {
+	b.release.Builds = append(b.release.Builds, releases.BuildInfo{
+		OS:   os,
+		Arch: arch,
+		URL:  fmt.Sprintf("%s/%s/%s/%s", DownloadBaseURL, b.release.Name, b.release.Version, buildFilename(b.release, os, arch)),
+	})
+	return b
+}
+
+// Prerelease marks the release as a prerelease.
+func (b *ReleaseBuilder) Prerelease() *ReleaseBuilder {
+	b.release.IsPrerelease = true
+	return b
+}
+
+// Unsupported marks the release as out of support.
+func (b *ReleaseBuilder) Unsupported() *ReleaseBuilder {
+	b.release.Status.State = releases.ReleaseStateUnsupported
+	return b
+}
+
+// Withdrawn marks the release as withdrawn, for the reason given in message.
+func (b *ReleaseBuilder) Withdrawn(message string) *ReleaseBuilder {
+	b.release.Status.State = releases.ReleaseStateWithdrawn
+	b.release.Status.Message = message
+	return b
+}
+
+// CreatedAt sets the time at which the release was created. Unless set, the release is assigned a
+// creation time when it is added to a Server.
+func (b *ReleaseBuilder) CreatedAt(created time.Time) *ReleaseBuilder {
+	b.release.TimestampCreated = created
+	return b
+}
+
+// WithChangelog sets the URL of the changelog covering the release.
+func (b *ReleaseBuilder) WithChangelog(url string) *ReleaseBuilder {
+	b.release.URLChangelog = url
+	return b
+}
+
+// Modify applies fn to the release metadata, in order to set fields for which the builder has no
+// method.
+func (b *ReleaseBuilder) Modify(fn func(*releases.ReleaseInfo)) *ReleaseBuilder {
+	fn(&b.release)
+	return b
+}
+
+// Build returns the release metadata. If the release has builds, it references a SHA256SUMS file
+// and its signatures. Timestamps which have not been set default to the creation time.
+func (b *ReleaseBuilder) Build() releases.ReleaseInfo {
+	release := b.release
+	release.Builds = slices.Clone(release.Builds)
+
+	if len(release.Builds) > 0 {
+		prefix := fmt.Sprintf("%s/%s/%s/%s_%s_SHA256SUMS", DownloadBaseURL, release.Name, release.Version, release.Name, release.Version)
+		release.URLSHASUMs = prefix
+		release.URLSHASUMsSignatures = []string{
+			prefix + ".sig",
+			prefix + "." + SigningKeyID + ".sig",
+		}
+	}
+
+	setDefaultTimestamps(&release)
+	return release
+}
+
+func setDefaultTimestamps(release *releases.ReleaseInfo) {
+	if release.TimestampUpdated.IsZero() {
+		release.TimestampUpdated = release.TimestampCreated
+	}
+	if release.Status.TimestampUpdated.IsZero() {
+		release.Status.TimestampUpdated = release.TimestampCreated
+	}
+}
+
+func buildFilename(release releases.ReleaseInfo, os string, arch string) string {
+	return fmt.Sprintf("%s_%s_%s_%s.zip", release.Name, release.Version, os, arch)
+}
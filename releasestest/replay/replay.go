@@ -0,0 +1,381 @@
+// Package replay provides an http.RoundTripper which records HTTP interactions, such as those with
+// the HashiCorp Releases API, to a golden file, and replays them from it, so that tests which
+// depend on a live service can run deterministically and offline.
+//
+// Requests are matched to recorded interactions by method and URL, disregarding the order of
+// query parameters. Values of sensitive headers are redacted before they are recorded.
+package replay
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+// Redacted is the value with which the values of redacted headers are replaced when recorded.
+const Redacted = "REDACTED"
+
+var (
+	// ErrUnmatchedRequest indicates that a request made in replay mode matches no recorded
+	// interaction.
+	ErrUnmatchedRequest = errors.New("no recorded interaction matches request")
+
+	// ErrInvalidGoldenFile indicates that a golden file could not be parsed.
+	ErrInvalidGoldenFile = errors.New("invalid golden file")
+)
+
+// Mode determines whether a Transport records or replays interactions.
+type Mode int
+
+const (
+	// ModeReplay serves each request from the golden file, without making requests to the network.
+	ModeReplay Mode = iota
+
+	// ModeRecord makes each request using the underlying transport, and records the interaction
+	// to the golden file when the Transport is closed.
+	ModeRecord
+)
+
+// defaultRedactedHeaders are the headers whose values are always redacted.
+var defaultRedactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+}
+
+// Opt is a functional option which can be used to configure a Transport via the New function.
+type Opt func(*transportOpts)
+
+type transportOpts struct {
+	mode        Mode
+	base        http.RoundTripper
+	redacted    []string
+	passthrough func(*http.Request) bool
+}
+
+// WithMode sets whether the Transport records or replays interactions. The default is ModeReplay.
+func WithMode(mode Mode) Opt {
+	return func(opts *transportOpts) {
+		opts.mode = mode
+	}
+}
+
+// WithBase sets the transport used to make requests in record mode, and requests selected using
+// WithPassthrough. If this option is not supplied, http.DefaultTransport is used.
+func WithBase(base http.RoundTripper) Opt {
+	return func(opts *transportOpts) {
+		if base != nil {
+			opts.base = base
+		}
+	}
+}
+
+// WithRedactedHeaders adds headers whose values are redacted before they are recorded, in addition
+// to Authorization, Cookie, Proxy-Authorization and Set-Cookie. Names are case-insensitive.
+func WithRedactedHeaders(names ...string) Opt {
+	return func(opts *transportOpts) {
+		opts.redacted = append(opts.redacted, names...)
+	}
+}
+
+// WithPassthrough configures a predicate selecting requests which are neither recorded nor
+// replayed, but made using the base transport in either mode. This is useful when an HTTP client
+// using the Transport, for example one supplied to releases.WithHTTPClient, also makes requests to
+// local test servers.
+func WithPassthrough(passthrough func(*http.Request) bool) Opt {
+	return func(opts *transportOpts) {
+		opts.passthrough = passthrough
+	}
+}
+
+// Transport is an http.RoundTripper which records interactions to, or replays them from, a golden
+// file. It is safe for concurrent use.
+type Transport struct {
+	path string
+	opts transportOpts
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     map[int]struct{}
+}
+
+// New returns a Transport using the golden file at path. In replay mode the file is read
+// immediately, and an error is returned if it does not exist or cannot be parsed. In record mode
+// the file is written, replacing any existing file, when the Transport is closed.
+func New(path string, opts ...Opt) (*Transport, error) {
+	t := &Transport{
+		path: path,
+		opts: transportOpts{
+			base:     http.DefaultTransport,
+			redacted: slices.Clone(defaultRedactedHeaders),
+		},
+		replayed: make(map[int]struct{}),
+	}
+	for _, opt := range opts {
+		opt(&t.opts)
+	}
+
+	if t.opts.mode == ModeRecord {
+		return t, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var golden goldenFile
+	if err := json.Unmarshal(data, &golden); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidGoldenFile, path, err)
+	}
+	for i, interaction := range golden.Interactions {
+		if _, err := interaction.Response.body(); err != nil {
+			return nil, fmt.Errorf("%w: %s: interaction %d: %w", ErrInvalidGoldenFile, path, i, err)
+		}
+		golden.Interactions[i].Request.URL = normalizeURL(interaction.Request.URL)
+	}
+	t.interactions = golden.Interactions
+
+	return t, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.opts.passthrough != nil && t.opts.passthrough(req) {
+		return t.opts.base.RoundTrip(req)
+	}
+
+	if t.opts.mode == ModeRecord {
+		return t.record(req)
+	}
+	return t.replay(req)
+}
+
+// Close writes the golden file in record mode. In replay mode it does nothing.
+func (t *Transport) Close() error {
+	if t.opts.mode != ModeRecord {
+		return nil
+	}
+
+	t.mu.Lock()
+	data, err := json.MarshalIndent(goldenFile{Interactions: t.interactions}, "", "  ")
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(t.path, append(data, '\n'), 0o644)
+}
+
+func (t *Transport) record(req *http.Request) (*http.Response, error) {
+	resp, err := t.opts.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    normalizeURL(req.URL.String()),
+			Header: t.redact(req.Header),
+		},
+		Response: newResponse(resp.StatusCode, t.redact(resp.Header), body),
+	}
+
+	// Identical interactions are recorded once, since the last matching interaction is replayed
+	// repeatedly.
+	t.mu.Lock()
+	if !slices.ContainsFunc(t.interactions, func(existing Interaction) bool {
+		return reflect.DeepEqual(existing, interaction)
+	}) {
+		t.interactions = append(t.interactions, interaction)
+	}
+	t.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay serves req from the first matching interaction which has not yet been replayed. Once all
+// matching interactions have been replayed, the last of them is replayed again, so that repeated
+// runs of the same test succeed.
+func (t *Transport) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	normalized := normalizeURL(req.URL.String())
+
+	t.mu.Lock()
+	match := -1
+	for i, interaction := range t.interactions {
+		if interaction.Request.Method != method || interaction.Request.URL != normalized {
+			continue
+		}
+		match = i
+		if _, ok := t.replayed[i]; !ok {
+			break
+		}
+	}
+	if match >= 0 {
+		t.replayed[match] = struct{}{}
+	}
+	t.mu.Unlock()
+
+	if match < 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrUnmatchedRequest, method, normalized)
+	}
+
+	recorded := t.interactions[match].Response
+	body, _ := recorded.body()
+
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (t *Transport) redact(header http.Header) http.Header {
+	result := header.Clone()
+	for _, name := range t.opts.redacted {
+		name = http.CanonicalHeaderKey(name)
+		if values, ok := result[name]; ok {
+			result[name] = slices.Repeat([]string{Redacted}, len(values))
+		}
+	}
+	return result
+}
+
+// normalizeURL returns rawURL with its query parameters sorted by name, so that requests which
+// differ only in the order of their query parameters are matched. The order of values of a single
+// parameter is preserved. URLs which cannot be parsed are returned unchanged.
+func normalizeURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	parsed.RawQuery = parsed.Query().Encode()
+	parsed.Fragment = ""
+	return parsed.String()
+}
+
+type goldenFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response with which it was served, as recorded in a golden file.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. Its URL has normalized query parameter order, and the values of
+// redacted headers are replaced with Redacted.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// Body encodings with which response bodies are recorded.
+const (
+	encodingJSON   = "json"
+	encodingText   = "text"
+	encodingBase64 = "base64"
+)
+
+// Response is a recorded response. Bodies which are valid JSON are recorded as JSON values, so that
+// golden files are readable and diff cleanly, and are replayed in compact form. Other bodies are
+// recorded as strings, encoded as base64 if they are not valid UTF-8.
+type Response struct {
+	StatusCode   int             `json:"status_code"`
+	Header       http.Header     `json:"header,omitempty"`
+	BodyEncoding string          `json:"body_encoding,omitempty"`
+	Body         json.RawMessage `json:"body,omitempty"`
+}
+
+func newResponse(statusCode int, header http.Header, body []byte) Response {
+	header.Del("Content-Length")
+	response := Response{
+		StatusCode: statusCode,
+		Header:     header,
+	}
+
+	switch {
+	case len(body) == 0:
+	case json.Valid(body):
+		response.BodyEncoding = encodingJSON
+		response.Body = body
+	case utf8.Valid(body):
+		response.BodyEncoding = encodingText
+		response.Body, _ = json.Marshal(string(body))
+	default:
+		response.BodyEncoding = encodingBase64
+		response.Body, _ = json.Marshal(base64.StdEncoding.EncodeToString(body))
+	}
+	return response
+}
+
+// body returns the decoded response body.
+func (r Response) body() ([]byte, error) {
+	switch r.BodyEncoding {
+	case "":
+		return nil, nil
+	case encodingJSON:
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, r.Body); err != nil {
+			return nil, err
+		}
+		return compacted.Bytes(), nil
+	case encodingText, encodingBase64:
+		var text string
+		if err := json.Unmarshal(r.Body, &text); err != nil {
+			return nil, err
+		}
+		if r.BodyEncoding == encodingText {
+			return []byte(text), nil
+		}
+		return base64.StdEncoding.DecodeString(text)
+	default:
+		return nil, fmt.Errorf("unknown body encoding %q", r.BodyEncoding)
+	}
+}
@@ -0,0 +1,197 @@
+package replay_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jen20/go-hashicorp-releases-client/releasestest/replay"
+)
+
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+}
+
+func get(t *testing.T, client *http.Client, url string, header http.Header) (*http.Response, string, error) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	requireNoError(t, err)
+	req.Header = header
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	requireNoError(t, err)
+	return resp, string(body), nil
+}
+
+// jsonEqual returns true if a and b are equivalent JSON documents. JSON bodies are replayed in
+// compact form, so are not necessarily identical to those recorded.
+func jsonEqual(t *testing.T, a string, b string) bool {
+	t.Helper()
+
+	var decodedA, decodedB any
+	requireNoError(t, json.Unmarshal([]byte(a), &decodedA))
+	requireNoError(t, json.Unmarshal([]byte(b), &decodedB))
+	return reflect.DeepEqual(decodedA, decodedB)
+}
+
+func TestTransport_RecordAndReplay(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Set-Cookie", "session=secret")
+		switch r.URL.Path {
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"query": %q, "request": %d}`, r.URL.RawQuery, requests)
+		case "/binary":
+			_, _ = w.Write([]byte{0xff, 0x00, 0xfe})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "golden.json")
+
+	recorder, err := replay.New(path, replay.WithMode(replay.ModeRecord), replay.WithRedactedHeaders("x-api-key"))
+	requireNoError(t, err)
+	client := &http.Client{Transport: recorder}
+
+	header := http.Header{"X-Api-Key": {"secret"}, "Authorization": {"Bearer secret"}}
+	_, recordedJSON, err := get(t, client, server.URL+"/json?b=2&a=1", header)
+	requireNoError(t, err)
+	_, recordedBinary, err := get(t, client, server.URL+"/binary", header)
+	requireNoError(t, err)
+	_, _, err = get(t, client, server.URL+"/missing", header)
+	requireNoError(t, err)
+	requireNoError(t, recorder.Close())
+
+	golden, err := os.ReadFile(path)
+	requireNoError(t, err)
+	if strings.Contains(string(golden), "secret") {
+		t.Fatalf("golden file contains unredacted header values:\n%s", golden)
+	}
+
+	player, err := replay.New(path)
+	requireNoError(t, err)
+	client = &http.Client{Transport: player}
+	recordedRequests := requests
+
+	resp, body, err := get(t, client, server.URL+"/json?a=1&b=2", nil)
+	requireNoError(t, err)
+	if resp.StatusCode != http.StatusOK || !jsonEqual(t, body, recordedJSON) {
+		t.Fatalf("expected recorded response %q, got %d %q", recordedJSON, resp.StatusCode, body)
+	}
+	if resp.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("expected recorded Content-Type, got %q", resp.Header.Get("Content-Type"))
+	}
+
+	_, body, err = get(t, client, server.URL+"/binary", nil)
+	requireNoError(t, err)
+	if body != recordedBinary {
+		t.Fatalf("expected recorded response %q, got %q", recordedBinary, body)
+	}
+
+	resp, _, err = get(t, client, server.URL+"/missing", nil)
+	requireNoError(t, err)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected recorded status code 404, got %d", resp.StatusCode)
+	}
+
+	// Interactions may be replayed repeatedly.
+	_, body, err = get(t, client, server.URL+"/json?b=2&a=1", nil)
+	requireNoError(t, err)
+	if !jsonEqual(t, body, recordedJSON) {
+		t.Fatalf("expected recorded response %q, got %q", recordedJSON, body)
+	}
+
+	if requests != recordedRequests {
+		t.Fatalf("expected no requests to be made in replay mode, got %d", requests-recordedRequests)
+	}
+
+	_, _, err = get(t, client, server.URL+"/json?a=2", nil)
+	if !errors.Is(err, replay.ErrUnmatchedRequest) {
+		t.Fatalf("expected ErrUnmatchedRequest, got: %v", err)
+	}
+}
+
+func TestTransport_ReplayInOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "golden.json")
+	golden := `{
+  "interactions": [
+    {"request": {"method": "GET", "url": "https://example.com/status"}, "response": {"status_code": 503}},
+    {"request": {"method": "GET", "url": "https://example.com/status"}, "response": {"status_code": 200, "body_encoding": "text", "body": "ok"}}
+  ]
+}`
+	requireNoError(t, os.WriteFile(path, []byte(golden), 0o644))
+
+	player, err := replay.New(path)
+	requireNoError(t, err)
+	client := &http.Client{Transport: player}
+
+	for _, expected := range []int{http.StatusServiceUnavailable, http.StatusOK, http.StatusOK} {
+		resp, _, err := get(t, client, "https://example.com/status", nil)
+		requireNoError(t, err)
+		if resp.StatusCode != expected {
+			t.Fatalf("expected status code %d, got %d", expected, resp.StatusCode)
+		}
+	}
+}
+
+func TestTransport_Passthrough(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "live")
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "golden.json")
+	requireNoError(t, os.WriteFile(path, []byte(`{"interactions": []}`), 0o644))
+
+	player, err := replay.New(path, replay.WithPassthrough(func(req *http.Request) bool {
+		return req.URL.Path == "/live"
+	}))
+	requireNoError(t, err)
+	client := &http.Client{Transport: player}
+
+	_, body, err := get(t, client, server.URL+"/live", nil)
+	requireNoError(t, err)
+	if body != "live" {
+		t.Fatalf("expected live response, got %q", body)
+	}
+
+	_, _, err = get(t, client, server.URL+"/other", nil)
+	if !errors.Is(err, replay.ErrUnmatchedRequest) {
+		t.Fatalf("expected ErrUnmatchedRequest, got: %v", err)
+	}
+}
+
+func TestNew_InvalidGoldenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "golden.json")
+	requireNoError(t, os.WriteFile(path, []byte(`{"interactions": [{"response": {"body_encoding": "rot13"}}]}`), 0o644))
+
+	_, err := replay.New(path)
+	if !errors.Is(err, replay.ErrInvalidGoldenFile) {
+		t.Fatalf("expected ErrInvalidGoldenFile, got: %v", err)
+	}
+}
@@ -0,0 +1,313 @@
+// Package releasestest provides a fake implementation of the HashiCorp Releases API, with which to
+// test code built on releases.Client without making requests to the production API.
+package releasestest
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+const (
+	// MediaType is the content type with which the fake API serves responses.
+	MediaType = "application/vnd+hashicorp.releases-api.v1+json"
+
+	defaultPageSize = 10
+	maxPageSize     = 20
+)
+
+// defaultCreated is the creation time assigned to the first release added to a Server without
+// one. Each subsequent release is assigned a time one hour later.
+var defaultCreated = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Opt is a functional option which can be used to configure a Server via the NewServer function.
+type Opt func(*serverOpts)
+
+type serverOpts struct {
+	artifacts bool
+	tls       bool
+}
+
+// WithArtifacts causes the Server to serve the artifacts of each release added to it: a zip
+// archive for each build, the SHA256SUMS file listing their checksums, and placeholder signatures.
+// The URLs of these artifacts in the release metadata are rewritten to refer to the Server. Since
+// releases.ReleaseInfo.Validate requires https URLs, this option implies WithTLS.
+func WithArtifacts() Opt {
+	return func(opts *serverOpts) {
+		opts.artifacts = true
+		opts.tls = true
+	}
+}
+
+// WithTLS causes the Server to serve HTTPS rather than HTTP. Clients returned by NewClient trust
+// its certificate.
+func WithTLS() Opt {
+	return func(opts *serverOpts) {
+		opts.tls = true
+	}
+}
+
+// Server is a fake Releases API, serving the releases added to it using Add. It emulates the
+// products, releases, latest release and release version endpoints of the API, including license
+// class filtering, pagination and "404 Not Found" responses for unknown products and versions.
+// Server embeds an *httptest.Server, which must be closed after use.
+type Server struct {
+	*httptest.Server
+
+	opts serverOpts
+
+	mu          sync.Mutex
+	products    map[string][]releases.ReleaseInfo
+	artifacts   map[string][]byte
+	nextCreated time.Time
+}
+
+// NewServer starts and returns a new Server, to which releases may be added using Add.
+func NewServer(opts ...Opt) *Server {
+	s := &Server{
+		products:    make(map[string][]releases.ReleaseInfo),
+		artifacts:   make(map[string][]byte),
+		nextCreated: defaultCreated,
+	}
+	for _, opt := range opts {
+		opt(&s.opts)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/products", s.serveProducts)
+	mux.HandleFunc("GET /v1/releases/{product}", s.serveReleases)
+	mux.HandleFunc("GET /v1/releases/{product}/{version}", s.serveRelease)
+	mux.HandleFunc("GET /", s.serveArtifact)
+
+	if s.opts.tls {
+		s.Server = httptest.NewTLSServer(mux)
+	} else {
+		s.Server = httptest.NewServer(mux)
+	}
+	return s
+}
+
+// NewClient returns a releases.Client which makes requests to the Server, configured using the
+// supplied options in addition.
+func (s *Server) NewClient(opts ...releases.ClientOpt) (*releases.Client, error) {
+	return releases.New(append([]releases.ClientOpt{
+		releases.WithBaseURL(s.URL),
+		releases.WithHTTPClient(s.Client()),
+	}, opts...)...)
+}
+
+// Add adds releases to the Server, replacing any existing releases of the same product and
+// version. Releases without a creation time are assigned one later than that of any release
+// previously added without one, so that they are served as the newest.
+func (s *Server) Add(items ...releases.ReleaseInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range items {
+		if item.TimestampCreated.IsZero() {
+			item.TimestampCreated = s.nextCreated
+			s.nextCreated = s.nextCreated.Add(time.Hour)
+		}
+		setDefaultTimestamps(&item)
+
+		if s.opts.artifacts {
+			item = s.addArtifacts(item)
+		}
+
+		existing := slices.DeleteFunc(s.products[item.Name], func(r releases.ReleaseInfo) bool {
+			return r.Version == item.Version
+		})
+		existing = append(existing, item)
+		slices.SortStableFunc(existing, func(a, b releases.ReleaseInfo) int {
+			return b.TimestampCreated.Compare(a.TimestampCreated)
+		})
+		s.products[item.Name] = existing
+	}
+}
+
+// addArtifacts generates the artifacts of release, and returns release with their URLs rewritten
+// to refer to the Server.
+func (s *Server) addArtifacts(release releases.ReleaseInfo) releases.ReleaseInfo {
+	release.Builds = slices.Clone(release.Builds)
+
+	var sums strings.Builder
+	for i, build := range release.Builds {
+		filename := buildFilename(release, build.OS, build.Arch)
+		path := fmt.Sprintf("/%s/%s/%s", release.Name, release.Version, filename)
+
+		content := buildArchive(release, build)
+		s.artifacts[path] = content
+		_, _ = fmt.Fprintf(&sums, "%x  %s\n", sha256.Sum256(content), filename)
+
+		release.Builds[i].URL = s.URL + path
+	}
+
+	if len(release.Builds) == 0 {
+		return release
+	}
+
+	sumsPath := fmt.Sprintf("/%s/%s/%s_%s_SHA256SUMS", release.Name, release.Version, release.Name, release.Version)
+	s.artifacts[sumsPath] = []byte(sums.String())
+	s.artifacts[sumsPath+".sig"] = []byte("placeholder signature\n")
+	s.artifacts[sumsPath+"."+SigningKeyID+".sig"] = []byte("placeholder signature\n")
+
+	release.URLSHASUMs = s.URL + sumsPath
+	release.URLSHASUMsSignatures = []string{
+		s.URL + sumsPath + ".sig",
+		s.URL + sumsPath + "." + SigningKeyID + ".sig",
+	}
+	return release
+}
+
+// buildArchive returns a zip archive containing a single file named for the product, whose
+// content identifies the build. Archives are deterministic, so their checksums are stable.
+func buildArchive(release releases.ReleaseInfo, build releases.BuildInfo) []byte {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	w, err := archive.CreateHeader(&zip.FileHeader{
+		Name:     release.Name,
+		Method:   zip.Deflate,
+		Modified: release.TimestampCreated,
+	})
+	if err == nil {
+		_, err = fmt.Fprintf(w, "%s %s %s/%s\n", release.Name, release.Version, build.OS, build.Arch)
+	}
+	if err == nil {
+		err = archive.Close()
+	}
+	if err != nil {
+		panic(fmt.Sprintf("writing archive to memory: %s", err))
+	}
+
+	return buf.Bytes()
+}
+
+func (s *Server) serveProducts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	products := slices.Sorted(maps.Keys(s.products))
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, products)
+}
+
+func (s *Server) serveReleases(w http.ResponseWriter, r *http.Request) {
+	items, ok := s.filteredReleases(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+
+	limit := defaultPageSize
+	if query.Has("limit") {
+		var err error
+		if limit, err = strconv.Atoi(query.Get("limit")); err != nil || limit < 1 || limit > maxPageSize {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxPageSize))
+			return
+		}
+	}
+
+	if query.Has("after") {
+		after, err := time.Parse(time.RFC3339Nano, query.Get("after"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "after must be an RFC 3339 timestamp")
+			return
+		}
+		items = slices.DeleteFunc(items, func(item releases.ReleaseInfo) bool {
+			return !item.TimestampCreated.Before(after)
+		})
+	}
+
+	writeJSON(w, http.StatusOK, items[:min(limit, len(items))])
+}
+
+func (s *Server) serveRelease(w http.ResponseWriter, r *http.Request) {
+	items, ok := s.filteredReleases(w, r)
+	if !ok {
+		return
+	}
+
+	version := r.PathValue("version")
+	for _, item := range items {
+		if version == "latest" && !item.IsPrerelease || item.Version == version {
+			writeJSON(w, http.StatusOK, item)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Sprintf("release %s of %s not found", version, r.PathValue("product")))
+}
+
+// filteredReleases returns the releases of the product named in the request path, newest first,
+// filtered by the license class named in the query. If the product does not exist or the license
+// class is invalid, an error is written and false is returned.
+func (s *Server) filteredReleases(w http.ResponseWriter, r *http.Request) ([]releases.ReleaseInfo, bool) {
+	product := r.PathValue("product")
+
+	s.mu.Lock()
+	items, ok := s.products[product]
+	items = slices.Clone(items)
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("product %s not found", product))
+		return nil, false
+	}
+
+	if licenseClass := releases.LicenseClass(r.URL.Query().Get("license_class")); licenseClass != *releases.LicenseClassAny {
+		switch licenseClass {
+		case *releases.LicenseClassOSS, *releases.LicenseClassEnterprise, *releases.LicenseClassHCP:
+		default:
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid license class %q", licenseClass))
+			return nil, false
+		}
+
+		items = slices.DeleteFunc(items, func(item releases.ReleaseInfo) bool {
+			return item.LicenseClass != licenseClass
+		})
+	}
+
+	return items, true
+}
+
+func (s *Server) serveArtifact(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	content, ok := s.artifacts[r.URL.Path]
+	s.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(content)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", MediaType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{
+		"code":    status,
+		"message": message,
+	})
+}
@@ -0,0 +1,232 @@
+package releasestest_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+	"github.com/jen20/go-hashicorp-releases-client/releasestest"
+)
+
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+}
+
+func requireEqual(t *testing.T, expected any, actual any) {
+	t.Helper()
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("Not equal:\nexpected: %T(%#v)\n  actual: %T(%#v)", expected, expected, actual, actual)
+	}
+}
+
+func newTestClient(t *testing.T, server *releasestest.Server) *releases.Client {
+	t.Helper()
+
+	client, err := server.NewClient()
+	requireNoError(t, err)
+	return client
+}
+
+func versions(items []releases.ReleaseInfo) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.Version)
+	}
+	return result
+}
+
+func TestReleaseBuilder(t *testing.T) {
+	created := time.Date(2023, time.September, 27, 15, 0, 0, 0, time.UTC)
+
+	release := releasestest.NewRelease("vault", "1.15.0").
+		WithLicenseClass(releases.LicenseClassEnterprise).
+		WithBuild("linux", "amd64").
+		CreatedAt(created).
+		Build()
+
+	requireNoError(t, release.Validate())
+	requireEqual(t, releases.LicenseClass("enterprise"), release.LicenseClass)
+	requireEqual(t, "https://releases.hashicorp.com/vault/1.15.0/vault_1.15.0_linux_amd64.zip", release.Builds[0].URL)
+	requireEqual(t, "https://releases.hashicorp.com/vault/1.15.0/vault_1.15.0_SHA256SUMS", release.URLSHASUMs)
+	requireEqual(t, created, release.TimestampUpdated)
+	requireEqual(t, created, release.Status.TimestampUpdated)
+}
+
+func TestServer_Products(t *testing.T) {
+	server := releasestest.NewServer()
+	defer server.Close()
+
+	server.Add(
+		releasestest.NewRelease("vault", "1.15.0").Build(),
+		releasestest.NewRelease("consul", "1.16.0").Build(),
+	)
+
+	products, err := newTestClient(t, server).Products(context.Background())
+	requireNoError(t, err)
+	requireEqual(t, []string{"consul", "vault"}, products)
+}
+
+func TestServer_Releases(t *testing.T) {
+	server := releasestest.NewServer()
+	defer server.Close()
+
+	var expected []string
+	for i := range 40 {
+		version := fmt.Sprintf("1.%d.0", i)
+		server.Add(releasestest.NewRelease("terraform", version).Build())
+		expected = append([]string{version}, expected...)
+	}
+
+	items, err := newTestClient(t, server).Releases(context.Background(), "terraform", nil)
+	requireNoError(t, err)
+
+	var actual []string
+	for item, err := range items {
+		requireNoError(t, err)
+		actual = append(actual, item.Version)
+	}
+	requireEqual(t, expected, actual)
+}
+
+func TestServer_Releases_Pagination(t *testing.T) {
+	server := releasestest.NewServer()
+	defer server.Close()
+
+	for i := range 3 {
+		server.Add(releasestest.NewRelease("terraform", fmt.Sprintf("1.%d.0", i)).Build())
+	}
+
+	get := func(query string) (int, []releases.ReleaseInfo) {
+		t.Helper()
+
+		resp, err := server.Client().Get(server.URL + "/v1/releases/terraform?" + query)
+		requireNoError(t, err)
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+
+		var items []releases.ReleaseInfo
+		if resp.StatusCode == http.StatusOK {
+			requireNoError(t, json.NewDecoder(resp.Body).Decode(&items))
+		}
+		return resp.StatusCode, items
+	}
+
+	status, items := get("limit=2")
+	requireEqual(t, http.StatusOK, status)
+	requireEqual(t, []string{"1.2.0", "1.1.0"}, versions(items))
+
+	status, items = get("limit=2&after=" + items[1].TimestampCreated.Format(time.RFC3339Nano))
+	requireEqual(t, http.StatusOK, status)
+	requireEqual(t, []string{"1.0.0"}, versions(items))
+
+	status, _ = get("limit=21")
+	requireEqual(t, http.StatusBadRequest, status)
+
+	status, _ = get("after=yesterday")
+	requireEqual(t, http.StatusBadRequest, status)
+}
+
+func TestServer_LicenseClass(t *testing.T) {
+	server := releasestest.NewServer()
+	defer server.Close()
+
+	server.Add(
+		releasestest.NewRelease("vault", "1.15.0").Build(),
+		releasestest.NewRelease("vault", "1.15.0+ent").WithLicenseClass(releases.LicenseClassEnterprise).Build(),
+	)
+	client := newTestClient(t, server)
+
+	latest, err := client.LatestRelease(context.Background(), "vault", releases.LicenseClassOSS)
+	requireNoError(t, err)
+	requireEqual(t, "1.15.0", latest.Version)
+
+	latest, err = client.LatestRelease(context.Background(), "vault", releases.LicenseClassEnterprise)
+	requireNoError(t, err)
+	requireEqual(t, "1.15.0+ent", latest.Version)
+
+	_, err = client.LatestRelease(context.Background(), "vault", releases.LicenseClassHCP)
+	if !errors.Is(err, releases.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+}
+
+func TestServer_Latest(t *testing.T) {
+	server := releasestest.NewServer()
+	defer server.Close()
+
+	server.Add(
+		releasestest.NewRelease("consul", "1.16.0").Build(),
+		releasestest.NewRelease("consul", "1.17.0-rc1").Prerelease().Build(),
+	)
+
+	latest, err := newTestClient(t, server).LatestRelease(context.Background(), "consul", nil)
+	requireNoError(t, err)
+	requireEqual(t, "1.16.0", latest.Version)
+}
+
+func TestServer_NotFound(t *testing.T) {
+	server := releasestest.NewServer()
+	defer server.Close()
+
+	server.Add(releasestest.NewRelease("consul", "1.16.0").Build())
+	client := newTestClient(t, server)
+
+	_, err := client.Release(context.Background(), "consul", "1.0.0")
+	if !errors.Is(err, releases.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown version, got: %v", err)
+	}
+
+	_, err = client.LatestRelease(context.Background(), "nomad", nil)
+	if !errors.Is(err, releases.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown product, got: %v", err)
+	}
+}
+
+func TestServer_Artifacts(t *testing.T) {
+	server := releasestest.NewServer(releasestest.WithArtifacts())
+	defer server.Close()
+
+	server.Add(releasestest.NewRelease("terraform", "1.6.0").
+		WithBuild("linux", "amd64").
+		WithBuild("darwin", "arm64").
+		Build())
+	client := newTestClient(t, server)
+
+	release, err := client.Release(context.Background(), "terraform", "1.6.0")
+	requireNoError(t, err)
+
+	checksums, err := client.Checksums(context.Background(), release)
+	requireNoError(t, err)
+	requireEqual(t, 2, checksums.Len())
+
+	for _, build := range release.Builds {
+		expected, ok := checksums.LookupBuild(build)
+		if !ok {
+			t.Fatalf("no checksum for %s", build.URL)
+		}
+
+		resp, err := server.Client().Get(build.URL)
+		requireNoError(t, err)
+		h := sha256.New()
+		_, err = io.Copy(h, resp.Body)
+		_ = resp.Body.Close()
+		requireNoError(t, err)
+
+		requireEqual(t, expected.Hex(), hex.EncodeToString(h.Sum(nil)))
+	}
+}
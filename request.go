@@ -0,0 +1,251 @@
+package releases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+)
+
+const (
+	retryBaseDelay = 100 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
+// CallOpt is a functional option which can be used to configure a single call to a method of
+// Client, such as Release, overriding the configuration of the Client for that call only.
+type CallOpt func(*callOpts) error
+
+type callOpts struct {
+	endpoints   []url.URL
+	timeout     time.Duration
+	header      http.Header
+	bypassCache bool
+	retries     int
+	servedBy    func(endpoint string)
+}
+
+func (c *Client) newCallOpts(opts ...CallOpt) (callOpts, error) {
+	effectiveOpts := callOpts{
+		endpoints: append([]url.URL{c.opts.baseURL}, c.opts.fallbackURLs...),
+		header:    http.Header{},
+		retries:   c.opts.retries,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&effectiveOpts); err != nil {
+			return callOpts{}, err
+		}
+	}
+	return effectiveOpts, nil
+}
+
+// WithCallTimeout limits the time taken by each request made by the call, including any retries
+// and reading the response. For calls returning a sequence of pages, such as ReleasesPaged, the
+// limit applies to each page. Values which are not positive are rejected.
+func WithCallTimeout(timeout time.Duration) CallOpt {
+	return func(opts *callOpts) error {
+		if timeout <= 0 {
+			return fmt.Errorf("%w: must be positive, got %s", ErrInvalidTimeout, timeout)
+		}
+		opts.timeout = timeout
+		return nil
+	}
+}
+
+// WithCallHeader adds an HTTP header to each request made by the call. Headers set this way
+// replace any of the same name which the Client would otherwise send, such as User-Agent.
+func WithCallHeader(key string, value string) CallOpt {
+	return func(opts *callOpts) error {
+		opts.header.Add(key, value)
+		return nil
+	}
+}
+
+// WithCallCacheBypass asks any HTTP caches between the Client and the API, such as a CDN or a
+// caching proxy, to revalidate their responses to requests made by the call.
+func WithCallCacheBypass() CallOpt {
+	return func(opts *callOpts) error {
+		opts.bypassCache = true
+		return nil
+	}
+}
+
+// WithCallRetries overrides the number of times a failed request made by the call is retried,
+// which is otherwise configured using WithRetries. Values less than 0 are rejected.
+func WithCallRetries(retries int) CallOpt {
+	return func(opts *callOpts) error {
+		if retries < 0 {
+			return fmt.Errorf("%w: must be at least 0, got %d", ErrInvalidRetries, retries)
+		}
+		opts.retries = retries
+		return nil
+	}
+}
+
+// WithCallBaseURL overrides the URL at which the root of the releases API may be reached, which
+// is otherwise configured using WithBaseURL or WithBaseURLs. Fallback URLs are not used.
+func WithCallBaseURL(baseURL string) CallOpt {
+	return func(opts *callOpts) error {
+		parsed, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		opts.endpoints = []url.URL{*parsed}
+		return nil
+	}
+}
+
+// WithCallServedBy registers a function which is called with the base URL of the endpoint which
+// served each response to the call, such as one of those configured using WithBaseURLs. For calls
+// returning a sequence of pages, such as ReleasesPaged, it is called once for each page.
+func WithCallServedBy(servedBy func(endpoint string)) CallOpt {
+	return func(opts *callOpts) error {
+		opts.servedBy = servedBy
+		return nil
+	}
+}
+
+// get makes a GET request for the given path and query to the endpoints of the releases API,
+// failing over between endpoints and retrying failures as configured, and returns the response
+// along with the endpoint which served it. The caller must close the body of the returned
+// response, which also releases any timeout applied to the call.
+func (c *Client) get(ctx context.Context, pathComponents string, query url.Values, opts callOpts) (*http.Response, url.URL, error) {
+	cancel := context.CancelFunc(func() {})
+	if opts.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+	}
+
+	for attempt := 0; ; attempt++ {
+		endpoints := c.health.order(opts.endpoints)
+		for i, endpoint := range endpoints {
+			resp, err := c.getFromEndpoint(ctx, endpoint, pathComponents, query, opts)
+
+			failed := ctx.Err() == nil && isRetryable(resp, err)
+			if failed {
+				c.health.markFailed(endpoint, c.opts.endpointCooldown)
+			}
+
+			lastEndpoint := i == len(endpoints)-1
+			if failed && (attempt < opts.retries || !lastEndpoint) {
+				var delay time.Duration
+				if lastEndpoint {
+					delay = retryDelay(resp, attempt)
+				}
+				if resp != nil {
+					_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+					_ = resp.Body.Close()
+				}
+				if err := sleepContext(ctx, delay); err != nil {
+					cancel()
+					return nil, url.URL{}, err
+				}
+				continue
+			}
+
+			if err != nil {
+				cancel()
+				return nil, url.URL{}, err
+			}
+			if !failed {
+				c.health.markHealthy(endpoint)
+			}
+			if opts.servedBy != nil {
+				opts.servedBy(endpoint.String())
+			}
+
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			return resp, endpoint, nil
+		}
+	}
+}
+
+func (c *Client) getFromEndpoint(ctx context.Context, endpoint url.URL, pathComponents string, query url.Values, opts callOpts) (*http.Response, error) {
+	reqURL := endpoint
+	reqURL.Path = path.Join(reqURL.Path, pathComponents)
+	reqURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConstructingRequest, err)
+	}
+	req.Header.Set("Accept", releasesAPIMediaType)
+	if c.opts.userAgent != nil {
+		req.Header.Set("User-Agent", *c.opts.userAgent)
+	}
+	if opts.bypassCache {
+		req.Header.Set("Cache-Control", "no-cache")
+		req.Header.Set("Pragma", "no-cache")
+	}
+	for key, values := range opts.header {
+		req.Header[key] = values
+	}
+
+	return c.opts.httpClient.Do(req)
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isRetryable returns true if a request which produced resp or err may succeed if repeated.
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, ErrConstructingRequest) && !isCredentialsError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryDelay returns the time to wait before retrying a request. The server's Retry-After header
+// is respected if present, and otherwise the delay doubles with each attempt.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	delay := min(retryBaseDelay<<min(attempt, 8), retryMaxDelay)
+	if resp == nil {
+		return delay
+	}
+
+	retryAfter := resp.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return min(time.Duration(seconds)*time.Second, retryMaxDelay)
+	}
+	if at, err := http.ParseTime(retryAfter); err == nil {
+		return min(max(time.Until(at), 0), retryMaxDelay)
+	}
+	return delay
+}
+
+// cancelOnClose releases the context of a request when its response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
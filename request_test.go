@@ -0,0 +1,128 @@
+package releases_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+func TestCallOpts(t *testing.T) {
+	server := httptest.NewServer(makeTestReleasesHandler(t))
+	defer server.Close()
+
+	t.Run("Headers", func(t *testing.T) {
+		var header http.Header
+		recorder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header.Clone()
+			makeTestReleasesHandler(t).ServeHTTP(w, r)
+		}))
+		defer recorder.Close()
+
+		client, err := releases.New(releases.WithBaseURL(recorder.URL), releases.WithUserAgent("test-agent"))
+		requireNoError(t, err)
+
+		_, err = client.Release(context.Background(), "waypoint", "0.1.0")
+		requireNoError(t, err)
+		requireEqual(t, "test-agent", header.Get("User-Agent"))
+		requireEqual(t, "", header.Get("Cache-Control"))
+
+		_, err = client.Release(context.Background(), "waypoint", "0.1.0",
+			releases.WithCallHeader("X-Request-Id", "abc123"),
+			releases.WithCallHeader("User-Agent", "handler-agent"),
+			releases.WithCallCacheBypass())
+		requireNoError(t, err)
+		requireEqual(t, "abc123", header.Get("X-Request-Id"))
+		requireEqual(t, "handler-agent", header.Get("User-Agent"))
+		requireEqual(t, "no-cache", header.Get("Cache-Control"))
+	})
+
+	t.Run("Base URL", func(t *testing.T) {
+		client, err := releases.New(releases.WithBaseURL("http://127.0.0.1:0"))
+		requireNoError(t, err)
+
+		release, err := client.LatestRelease(context.Background(), "waypoint", releases.LicenseClassOSS,
+			releases.WithCallBaseURL(server.URL))
+		requireNoError(t, err)
+		requireEqual(t, waypoint_0_11_4, release)
+
+		pages, err := client.ReleasesPaged(context.Background(), "waypoint", nil, releases.WithCallBaseURL(server.URL))
+		requireNoError(t, err)
+		requireEqual(t, 3, len(collectResults(t, pages)))
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		}))
+		defer slow.Close()
+
+		client, err := releases.New(releases.WithBaseURL(slow.URL))
+		requireNoError(t, err)
+
+		_, err = client.Products(context.Background(), releases.WithCallTimeout(50*time.Millisecond))
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		client, err := releases.New(releases.WithBaseURL(server.URL))
+		requireNoError(t, err)
+
+		if _, err := client.Release(context.Background(), "waypoint", "0.1.0", releases.WithCallTimeout(0)); !errors.Is(err, releases.ErrInvalidTimeout) {
+			t.Fatalf("expected ErrInvalidTimeout, got: %v", err)
+		}
+		if _, err := client.Products(context.Background(), releases.WithCallRetries(-1)); !errors.Is(err, releases.ErrInvalidRetries) {
+			t.Fatalf("expected ErrInvalidRetries, got: %v", err)
+		}
+	})
+}
+
+func TestRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch requests.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		makeTestReleasesHandler(t).ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL), releases.WithRetries(1))
+	requireNoError(t, err)
+
+	_, err = client.Release(context.Background(), "waypoint", "0.1.0")
+	if !errors.Is(err, releases.ErrInvalidStatusCode) {
+		t.Fatalf("expected ErrInvalidStatusCode after exhausting retries, got: %v", err)
+	}
+	requireEqual(t, int32(2), requests.Load())
+
+	requests.Store(0)
+	release, err := client.Release(context.Background(), "waypoint", "0.1.0", releases.WithCallRetries(2))
+	requireNoError(t, err)
+	requireEqual(t, waypoint_0_1_0, release)
+	requireEqual(t, int32(3), requests.Load())
+
+	requests.Store(0)
+	_, err = client.Release(context.Background(), "waypoint", "0.1.0", releases.WithCallRetries(0))
+	if !errors.Is(err, releases.ErrInvalidStatusCode) {
+		t.Fatalf("expected ErrInvalidStatusCode without retries, got: %v", err)
+	}
+	requireEqual(t, int32(1), requests.Load())
+}
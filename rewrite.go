@@ -0,0 +1,132 @@
+package releases
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RewriteRule maps URLs with a given prefix to a different prefix, for example to download
+// artifacts via a proxy such as Artifactory rather than directly from releases.hashicorp.com.
+type RewriteRule struct {
+	// From is the prefix of URLs to which the rule applies, for example
+	// "https://releases.hashicorp.com/terraform". The scheme may be omitted, in which case the
+	// rule applies to URLs of any scheme. The path prefix matches whole path segments only, and
+	// the host is matched case-insensitively.
+	From string
+
+	// To is the absolute URL with which the matched prefix is replaced, for example
+	// "https://artifactory.example.com/artifactory/hashicorp-releases/terraform".
+	To string
+}
+
+type rewriteRule struct {
+	scheme string
+	host   string
+	path   string
+	to     url.URL
+}
+
+func parseRewriteRule(rule RewriteRule) (rewriteRule, error) {
+	from := rule.From
+	if !strings.Contains(from, "://") {
+		from = "//" + from
+	}
+
+	parsedFrom, err := url.Parse(from)
+	if err != nil || parsedFrom.Host == "" || parsedFrom.RawQuery != "" || parsedFrom.Fragment != "" {
+		return rewriteRule{}, fmt.Errorf("%w: %q is not a URL prefix", ErrInvalidRewriteRule, rule.From)
+	}
+
+	parsedTo, err := url.Parse(rule.To)
+	if err != nil || !parsedTo.IsAbs() || parsedTo.Host == "" || parsedTo.RawQuery != "" || parsedTo.Fragment != "" {
+		return rewriteRule{}, fmt.Errorf("%w: %q is not an absolute URL", ErrInvalidRewriteRule, rule.To)
+	}
+
+	return rewriteRule{
+		scheme: strings.ToLower(parsedFrom.Scheme),
+		host:   strings.ToLower(parsedFrom.Host),
+		path:   strings.TrimSuffix(parsedFrom.Path, "/"),
+		to:     *parsedTo,
+	}, nil
+}
+
+// apply returns u rewritten by the rule, and whether the rule matched.
+func (r rewriteRule) apply(u *url.URL) (string, bool) {
+	if r.scheme != "" && !strings.EqualFold(u.Scheme, r.scheme) {
+		return "", false
+	}
+	if !strings.EqualFold(u.Host, r.host) {
+		return "", false
+	}
+
+	rest, ok := strings.CutPrefix(u.Path, r.path)
+	if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+		return "", false
+	}
+
+	rewritten := r.to
+	rewritten.Path = strings.TrimSuffix(rewritten.Path, "/") + rest
+	rewritten.RawPath = ""
+	rewritten.RawQuery = u.RawQuery
+	rewritten.Fragment = u.Fragment
+	return rewritten.String(), true
+}
+
+// WithURLRewrites configures rules with which to rewrite the URLs from which artifacts are
+// downloaded: the URL of each build, and those of the SHA256SUMS file and its signatures. Rules
+// are applied to the ReleaseInfo returned by methods such as Release and Releases, and by methods
+// which download artifacts, such as Checksums. They may also be applied to other URLs using
+// RewriteURL.
+//
+// The first rule which matches a URL is applied, and rules are not applied to the result. Since
+// download methods also rewrite the URLs of releases which have already been rewritten, rules
+// should not match the URLs they produce. Rules supplied by repeated use of this option are
+// appended. Credentials configured for the Client are sent to the hosts of rewritten URLs.
+func WithURLRewrites(rules ...RewriteRule) ClientOpt {
+	return func(opts *clientOpts) error {
+		for _, rule := range rules {
+			parsed, err := parseRewriteRule(rule)
+			if err != nil {
+				return err
+			}
+			opts.rewriteRules = append(opts.rewriteRules, parsed)
+		}
+		return nil
+	}
+}
+
+// RewriteURL returns rawURL rewritten according to the rules configured using WithURLRewrites. If
+// no rule matches, or rawURL cannot be parsed, it is returned unchanged.
+func (c *Client) RewriteURL(rawURL string) string {
+	if len(c.opts.rewriteRules) == 0 || rawURL == "" {
+		return rawURL
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	for _, rule := range c.opts.rewriteRules {
+		if rewritten, ok := rule.apply(parsed); ok {
+			return rewritten
+		}
+	}
+	return rawURL
+}
+
+// rewriteRelease applies the configured rewrite rules to the artifact URLs of release.
+func (c *Client) rewriteRelease(release *ReleaseInfo) {
+	if len(c.opts.rewriteRules) == 0 {
+		return
+	}
+
+	for i := range release.Builds {
+		release.Builds[i].URL = c.RewriteURL(release.Builds[i].URL)
+	}
+	release.URLSHASUMs = c.RewriteURL(release.URLSHASUMs)
+	for i := range release.URLSHASUMsSignatures {
+		release.URLSHASUMsSignatures[i] = c.RewriteURL(release.URLSHASUMsSignatures[i])
+	}
+}
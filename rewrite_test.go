@@ -0,0 +1,127 @@
+package releases_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+func TestClient_RewriteURL(t *testing.T) {
+	client, err := releases.New(releases.WithURLRewrites(
+		releases.RewriteRule{
+			From: "https://releases.hashicorp.com/terraform",
+			To:   "https://artifactory.example.com/artifactory/terraform-releases/",
+		},
+		releases.RewriteRule{
+			From: "Releases.HashiCorp.com",
+			To:   "https://proxy.example.com/hashicorp",
+		},
+	))
+	requireNoError(t, err)
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "https://releases.hashicorp.com/terraform/1.6.0/terraform_1.6.0_linux_amd64.zip",
+			expected: "https://artifactory.example.com/artifactory/terraform-releases/1.6.0/terraform_1.6.0_linux_amd64.zip",
+		},
+		{
+			input:    "https://releases.hashicorp.com/terraform-provider-aws/5.0.0/terraform-provider-aws_5.0.0_SHA256SUMS",
+			expected: "https://proxy.example.com/hashicorp/terraform-provider-aws/5.0.0/terraform-provider-aws_5.0.0_SHA256SUMS",
+		},
+		{
+			input:    "http://releases.hashicorp.com/vault/1.15.0/vault_1.15.0_SHA256SUMS.72D7468F.sig?download=1",
+			expected: "https://proxy.example.com/hashicorp/vault/1.15.0/vault_1.15.0_SHA256SUMS.72D7468F.sig?download=1",
+		},
+		{
+			input:    "https://github.com/hashicorp/terraform/blob/main/CHANGELOG.md",
+			expected: "https://github.com/hashicorp/terraform/blob/main/CHANGELOG.md",
+		},
+		{
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			requireEqual(t, tc.expected, client.RewriteURL(tc.input))
+		})
+	}
+}
+
+func TestWithURLRewrites_Invalid(t *testing.T) {
+	testCases := map[string]releases.RewriteRule{
+		"No Host":     {From: "/terraform", To: "https://proxy.example.com"},
+		"Query":       {From: "https://releases.hashicorp.com/?a=b", To: "https://proxy.example.com"},
+		"Relative To": {From: "https://releases.hashicorp.com", To: "/hashicorp"},
+	}
+
+	for name, rule := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := releases.New(releases.WithURLRewrites(rule)); !errors.Is(err, releases.ErrInvalidRewriteRule) {
+				t.Fatalf("expected ErrInvalidRewriteRule, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestWithURLRewrites_Releases(t *testing.T) {
+	var checksumsPath string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checksumsPath = r.URL.Path
+		_, _ = w.Write([]byte(testChecksums))
+	}))
+	defer proxy.Close()
+
+	api := httptest.NewServer(makeTestReleasesHandler(t))
+	defer api.Close()
+
+	client, err := releases.New(
+		releases.WithBaseURL(api.URL),
+		releases.WithURLRewrites(releases.RewriteRule{
+			From: "https://releases.hashicorp.com",
+			To:   proxy.URL + "/hashicorp",
+		}))
+	requireNoError(t, err)
+
+	assertRewritten := func(t *testing.T, release releases.ReleaseInfo) {
+		t.Helper()
+
+		urls := append([]string{release.URLSHASUMs}, release.URLSHASUMsSignatures...)
+		for _, build := range release.Builds {
+			urls = append(urls, build.URL)
+		}
+		for _, u := range urls {
+			if !strings.HasPrefix(u, proxy.URL+"/hashicorp/waypoint/") {
+				t.Fatalf("expected %q to be rewritten", u)
+			}
+		}
+	}
+
+	release, err := client.Release(context.Background(), "waypoint", "0.11.4")
+	requireNoError(t, err)
+	assertRewritten(t, release)
+	requireEqual(t, waypoint_0_11_4.URLProjectWebsite, release.URLProjectWebsite)
+
+	items, err := client.Releases(context.Background(), "waypoint", nil)
+	requireNoError(t, err)
+	for _, item := range collectResults(t, items) {
+		assertRewritten(t, item)
+	}
+
+	_, err = client.Checksums(context.Background(), waypoint_0_11_4)
+	requireNoError(t, err)
+	requireEqual(t, "/hashicorp/waypoint/0.11.4/waypoint_0.11.4_SHA256SUMS", checksumsPath)
+
+	signature, err := client.SelectSignature(release)
+	requireNoError(t, err)
+	requireEqual(t, proxy.URL+"/hashicorp/waypoint/0.11.4/waypoint_0.11.4_SHA256SUMS.72D7468F.sig", signature.URL)
+}
@@ -0,0 +1,196 @@
+package releases
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Snapshot is a read-only ReleasesAPI which serves a fixed set of releases from memory, for example
+// to make repeated queries against a consistent view of the API, or to serve releases captured
+// earlier while the API is unavailable. It is obtained using NewSnapshot or CaptureSnapshot, and is
+// safe for concurrent use.
+//
+// A Snapshot behaves as a Client constructed without WithAllowWithdrawn: LatestRelease returns a
+// *ReleaseWithdrawnError instead of the latest release if it has been withdrawn. Prereleases are
+// not considered by LatestRelease. Errors for products and releases which are not present wrap
+// ErrNotFound, but not ErrInvalidStatusCode, since no request is made. CallOpt options are applied,
+// so that invalid options are reported, but otherwise have no effect.
+type Snapshot struct {
+	products map[string][]ReleaseInfo
+}
+
+var _ ReleasesAPI = (*Snapshot)(nil)
+
+// NewSnapshot returns a Snapshot serving the given releases. Releases of each product are ordered
+// newest first by creation time, and if several releases of a product have the same version, the
+// last of them is served.
+func NewSnapshot(items ...ReleaseInfo) *Snapshot {
+	s := &Snapshot{
+		products: make(map[string][]ReleaseInfo),
+	}
+
+	for _, item := range items {
+		existing := slices.DeleteFunc(s.products[item.Name], func(r ReleaseInfo) bool {
+			return r.Version == item.Version
+		})
+		s.products[item.Name] = append(existing, cloneRelease(item))
+	}
+
+	for _, productReleases := range s.products {
+		slices.SortStableFunc(productReleases, func(a, b ReleaseInfo) int {
+			return b.TimestampCreated.Compare(a.TimestampCreated)
+		})
+	}
+
+	return s
+}
+
+// CaptureSnapshot returns a Snapshot of the releases of the nominated products of any license
+// class, retrieved using api. If no products are nominated, all products returned by
+// api.Products are captured.
+func CaptureSnapshot(ctx context.Context, api ReleasesAPI, products ...string) (*Snapshot, error) {
+	if len(products) == 0 {
+		var err error
+		if products, err = api.Products(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var captured []ReleaseInfo
+	for _, product := range products {
+		items, err := api.Releases(ctx, product, nil)
+		if err != nil {
+			return nil, err
+		}
+		for item, err := range items {
+			if err != nil {
+				return nil, fmt.Errorf("capturing releases of %s: %w", product, err)
+			}
+			captured = append(captured, item)
+		}
+	}
+
+	return NewSnapshot(captured...), nil
+}
+
+// Products returns the names of all products in the snapshot, in lexical order.
+func (s *Snapshot) Products(_ context.Context, opts ...CallOpt) ([]string, error) {
+	if _, err := applyCallOpts(opts); err != nil {
+		return nil, err
+	}
+
+	return slices.Sorted(maps.Keys(s.products)), nil
+}
+
+// Release returns all metadata for a specific version of a product.
+func (s *Snapshot) Release(_ context.Context, product string, version string, opts ...CallOpt) (ReleaseInfo, error) {
+	items, err := s.releases(product, nil, opts)
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+
+	for _, item := range items {
+		if item.Version == version {
+			return cloneRelease(item), nil
+		}
+	}
+	return ReleaseInfo{}, fmt.Errorf("%w: release %s of %s", ErrNotFound, version, product)
+}
+
+// LatestRelease returns all metadata for the latest release of a product with the given license
+// class, or of any license class if licenseClass is nil.
+func (s *Snapshot) LatestRelease(_ context.Context, product string, licenseClass *LicenseClass, opts ...CallOpt) (ReleaseInfo, error) {
+	items, err := s.releases(product, licenseClass, opts)
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+
+	for _, item := range items {
+		if !item.IsPrerelease {
+			if err := item.CheckWithdrawn(); err != nil {
+				return ReleaseInfo{}, err
+			}
+			return cloneRelease(item), nil
+		}
+	}
+	return ReleaseInfo{}, fmt.Errorf("%w: latest release of %s", ErrNotFound, product)
+}
+
+// Releases returns an iterator over the releases of a product with the given license class,
+// newest first.
+func (s *Snapshot) Releases(_ context.Context, product string, licenseClass *LicenseClass, opts ...CallOpt) (iter.Seq2[ReleaseInfo, error], error) {
+	items, err := s.releases(product, licenseClass, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(ReleaseInfo, error) bool) {
+		if items == nil {
+			_ = yield(ReleaseInfo{}, fmt.Errorf("%w: product %s", ErrNotFound, product))
+			return
+		}
+
+		for _, item := range items {
+			if !yield(cloneRelease(item), nil) {
+				return
+			}
+		}
+	}, nil
+}
+
+// ReleasesPaged returns an iterator over pages of the releases of a product with the given license
+// class, newest first. Pages contain the same number of releases as those requested from the API
+// by Client.
+func (s *Snapshot) ReleasesPaged(_ context.Context, product string, licenseClass *LicenseClass, opts ...CallOpt) (iter.Seq2[[]ReleaseInfo, error], error) {
+	items, err := s.releases(product, licenseClass, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func([]ReleaseInfo, error) bool) {
+		if items == nil {
+			_ = yield(nil, fmt.Errorf("%w: product %s", ErrNotFound, product))
+			return
+		}
+
+		for page := range slices.Chunk(items, releasesPageSize) {
+			if !yield(cloneReleases(page), nil) {
+				return
+			}
+		}
+	}, nil
+}
+
+// releases validates the arguments of a call, and returns the releases of product with the given
+// license class. If the product is not present, the result is nil; if no releases have the license
+// class, it is empty but not nil. The result must not be modified.
+func (s *Snapshot) releases(product string, licenseClass *LicenseClass, opts []CallOpt) ([]ReleaseInfo, error) {
+	if _, err := applyCallOpts(opts); err != nil {
+		return nil, err
+	}
+	if product == "" {
+		return nil, fmt.Errorf("%w: may not be empty", ErrInvalidProduct)
+	}
+	if err := validateLicenseClass(licenseClass); err != nil {
+		return nil, err
+	}
+
+	items, ok := s.products[product]
+	if !ok {
+		return nil, nil
+	}
+	if licenseClass == nil || *licenseClass == *LicenseClassAny {
+		return items, nil
+	}
+
+	filtered := make([]ReleaseInfo, 0, len(items))
+	for _, item := range items {
+		if item.LicenseClass == *licenseClass {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, nil
+}
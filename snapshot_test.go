@@ -0,0 +1,113 @@
+package releases_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+func TestSnapshot(t *testing.T) {
+	created := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	snapshot := releases.NewSnapshot(
+		releases.ReleaseInfo{Name: "vault", Version: "1.15.0", LicenseClass: "oss", TimestampCreated: created},
+		releases.ReleaseInfo{Name: "vault", Version: "1.15.0+ent", LicenseClass: "enterprise", TimestampCreated: created.Add(time.Minute)},
+		releases.ReleaseInfo{Name: "vault", Version: "1.16.0-rc1", LicenseClass: "oss", IsPrerelease: true, TimestampCreated: created.Add(time.Hour)},
+		releases.ReleaseInfo{Name: "consul", Version: "1.17.0", LicenseClass: "oss", TimestampCreated: created,
+			Status: releases.ReleaseStatus{State: releases.ReleaseStateWithdrawn, Message: "Withdrawn for testing"}},
+	)
+	ctx := context.Background()
+
+	products, err := snapshot.Products(ctx)
+	requireNoError(t, err)
+	requireEqual(t, []string{"consul", "vault"}, products)
+
+	release, err := snapshot.Release(ctx, "vault", "1.15.0")
+	requireNoError(t, err)
+	requireEqual(t, "1.15.0", release.Version)
+
+	release, err = snapshot.LatestRelease(ctx, "vault", nil)
+	requireNoError(t, err)
+	requireEqual(t, "1.15.0+ent", release.Version)
+
+	release, err = snapshot.LatestRelease(ctx, "vault", releases.LicenseClassOSS)
+	requireNoError(t, err)
+	requireEqual(t, "1.15.0", release.Version)
+
+	release, err = snapshot.LatestRelease(ctx, "consul", nil)
+	var withdrawnErr *releases.ReleaseWithdrawnError
+	if !errors.As(err, &withdrawnErr) {
+		t.Fatalf("expected a *ReleaseWithdrawnError, got: %v", err)
+	}
+	requireEqual(t, "1.17.0", withdrawnErr.Version)
+	requireEqual(t, releases.ReleaseInfo{}, release)
+
+	items, err := snapshot.Releases(ctx, "vault", nil)
+	requireNoError(t, err)
+	requireEqual(t, []string{"1.16.0-rc1", "1.15.0+ent", "1.15.0"}, versionsOf(collectResults(t, items)))
+
+	_, err = snapshot.Release(ctx, "vault", "0.1.0")
+	if !errors.Is(err, releases.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown version, got: %v", err)
+	}
+
+	items, err = snapshot.Releases(ctx, "nomad", nil)
+	requireNoError(t, err)
+	for _, err := range items {
+		if !errors.Is(err, releases.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound for unknown product, got: %v", err)
+		}
+	}
+
+	_, err = snapshot.Products(ctx, releases.WithCallRetries(-1))
+	if !errors.Is(err, releases.ErrInvalidRetries) {
+		t.Fatalf("expected ErrInvalidRetries, got: %v", err)
+	}
+}
+
+func TestSnapshot_ReadOnly(t *testing.T) {
+	snapshot := releases.NewSnapshot(waypoint_0_11_4)
+
+	release, err := snapshot.Release(context.Background(), "waypoint", "0.11.4")
+	requireNoError(t, err)
+	release.Builds[0].URL = "https://example.com/modified"
+
+	release, err = snapshot.Release(context.Background(), "waypoint", "0.11.4")
+	requireNoError(t, err)
+	requireEqual(t, waypoint_0_11_4, release)
+}
+
+func TestCaptureSnapshot(t *testing.T) {
+	server := httptest.NewServer(makeTestReleasesHandler(t))
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL))
+	requireNoError(t, err)
+
+	snapshot, err := releases.CaptureSnapshot(context.Background(), client, "waypoint")
+	requireNoError(t, err)
+
+	pages, err := snapshot.ReleasesPaged(context.Background(), "waypoint", releases.LicenseClassOSS)
+	requireNoError(t, err)
+
+	var sizes []int
+	var captured []releases.ReleaseInfo
+	for page, err := range pages {
+		requireNoError(t, err)
+		sizes = append(sizes, len(page))
+		captured = append(captured, page...)
+	}
+	requireEqual(t, []int{16, 16, 11}, sizes)
+	requireEqual(t, versionsOf(waypointReleases), versionsOf(captured))
+}
+
+func versionsOf(items []releases.ReleaseInfo) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.Version)
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package releases
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// WithdrawnRelease identifies a release which has been withdrawn, and the reason given.
+type WithdrawnRelease struct {
+	// Version is the version number of the withdrawn release.
+	Version string
+
+	// Message is the explanation supplied with the withdrawal in ReleaseStatus.Message.
+	Message string
+}
+
+// ReleaseLine summarizes the releases of a product which share a major and minor version number.
+type ReleaseLine struct {
+	// Line is the major and minor version number shared by the releases, for example "1.5".
+	Line string
+
+	// LatestPatch is the newest release in this line which has not been withdrawn. If every release
+	// in the line has been withdrawn, it is the newest of those.
+	LatestPatch ReleaseInfo
+
+	// State is the support state of LatestPatch.
+	State ReleaseState
+
+	// FirstReleased is the time at which the first release in this line was created.
+	FirstReleased time.Time
+
+	// LastReleased is the time at which the most recent release in this line was created.
+	LastReleased time.Time
+
+	// Withdrawn lists the releases in this line which have been withdrawn, newest first.
+	Withdrawn []WithdrawnRelease
+
+	// NewerLineExists is set to true if the product has a release line with a higher version number.
+	NewerLineExists bool
+}
+
+// SupportMatrix returns a summary of each major.minor release line of the nominated product and
+// license class, newest line first. Prereleases, and releases whose version numbers cannot be
+// parsed, are not included.
+func (c *Client) SupportMatrix(ctx context.Context, product string, licenseClass *LicenseClass) ([]ReleaseLine, error) {
+	return SupportMatrix(ctx, c, product, licenseClass)
+}
+
+// SupportMatrix returns a summary of each major.minor release line of the nominated product and
+// license class, using the releases retrieved from api, as described for Client.SupportMatrix.
+func SupportMatrix(ctx context.Context, api ReleasesAPI, product string, licenseClass *LicenseClass) ([]ReleaseLine, error) {
+	items, err := api.Releases(ctx, product, licenseClass)
+	if err != nil {
+		return nil, err
+	}
+
+	type candidate struct {
+		release ReleaseInfo
+		version version
+	}
+
+	byLine := make(map[version][]candidate)
+	for release, err := range items {
+		if err != nil {
+			return nil, err
+		}
+		if release.IsPrerelease {
+			continue
+		}
+
+		v, err := parseVersion(release.Version)
+		if err != nil || v.prerelease != "" {
+			continue
+		}
+
+		key := version{major: v.major, minor: v.minor}
+		byLine[key] = append(byLine[key], candidate{release: release, version: v})
+	}
+
+	keys := make([]version, 0, len(byLine))
+	for key := range byLine {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b version) int {
+		return b.compare(a)
+	})
+
+	result := make([]ReleaseLine, 0, len(keys))
+	for i, key := range keys {
+		candidates := byLine[key]
+		slices.SortStableFunc(candidates, func(a, b candidate) int {
+			return b.version.compare(a.version)
+		})
+
+		line := ReleaseLine{
+			Line:            key.line(),
+			LatestPatch:     candidates[0].release,
+			NewerLineExists: i > 0,
+		}
+
+		latestFound := false
+		for _, c := range candidates {
+			created := c.release.TimestampCreated
+			if line.FirstReleased.IsZero() || created.Before(line.FirstReleased) {
+				line.FirstReleased = created
+			}
+			if created.After(line.LastReleased) {
+				line.LastReleased = created
+			}
+
+			if c.release.Status.State == ReleaseStateWithdrawn {
+				line.Withdrawn = append(line.Withdrawn, WithdrawnRelease{
+					Version: c.release.Version,
+					Message: c.release.Status.Message,
+				})
+				continue
+			}
+			if !latestFound {
+				line.LatestPatch = c.release
+				latestFound = true
+			}
+		}
+		line.State = line.LatestPatch.Status.State
+
+		result = append(result, line)
+	}
+
+	return result, nil
+}
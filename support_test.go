@@ -0,0 +1,70 @@
+package releases_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+func TestClient_SupportMatrix(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+	release := func(version string, created time.Time, state releases.ReleaseState, message string) releases.ReleaseInfo {
+		return releases.ReleaseInfo{
+			Name:             "vault",
+			Version:          version,
+			IsPrerelease:     state == "",
+			Status:           releases.ReleaseStatus{State: state, Message: message},
+			TimestampCreated: created,
+		}
+	}
+
+	fixtures := []releases.ReleaseInfo{
+		release("1.16.0-rc1", day(20), "", ""),
+		release("1.15.2", day(18), releases.ReleaseStateWithdrawn, "Regression in raft snapshots"),
+		release("1.14.9", day(17), releases.ReleaseStateSupported, ""),
+		release("1.15.1", day(16), releases.ReleaseStateSupported, ""),
+		release("1.15.0", day(10), releases.ReleaseStateSupported, ""),
+		release("1.14.8", day(9), releases.ReleaseStateSupported, ""),
+		release("1.13.2", day(3), releases.ReleaseStateUnsupported, ""),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveTestReleasesPage(t, w, r, fixtures)
+	}))
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL))
+	requireNoError(t, err)
+
+	matrix, err := client.SupportMatrix(context.Background(), "vault", releases.LicenseClassOSS)
+	requireNoError(t, err)
+
+	requireEqual(t, 3, len(matrix))
+
+	requireEqual(t, "1.15", matrix[0].Line)
+	requireEqual(t, "1.15.1", matrix[0].LatestPatch.Version)
+	requireEqual(t, releases.ReleaseStateSupported, matrix[0].State)
+	requireEqual(t, day(10), matrix[0].FirstReleased)
+	requireEqual(t, day(18), matrix[0].LastReleased)
+	requireEqual(t, []releases.WithdrawnRelease{{Version: "1.15.2", Message: "Regression in raft snapshots"}}, matrix[0].Withdrawn)
+	requireEqual(t, false, matrix[0].NewerLineExists)
+
+	requireEqual(t, "1.14", matrix[1].Line)
+	requireEqual(t, "1.14.9", matrix[1].LatestPatch.Version)
+	requireEqual(t, 0, len(matrix[1].Withdrawn))
+	requireEqual(t, true, matrix[1].NewerLineExists)
+
+	requireEqual(t, "1.13", matrix[2].Line)
+	requireEqual(t, releases.ReleaseStateUnsupported, matrix[2].State)
+	requireEqual(t, true, matrix[2].NewerLineExists)
+
+	fromSnapshot, err := releases.SupportMatrix(context.Background(), releases.NewSnapshot(fixtures...), "vault", nil)
+	requireNoError(t, err)
+	requireEqual(t, matrix, fromSnapshot)
+}
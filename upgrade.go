@@ -0,0 +1,169 @@
+package releases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// UpgradeStep describes one release between the current and target versions of an upgrade.
+type UpgradeStep struct {
+	// Release is the metadata for the release.
+	Release ReleaseInfo
+
+	// Changelog is the section of the product changelog describing the release. It is nil if the
+	// release has no changelog, or the changelog does not describe the release.
+	Changelog *ChangelogVersion
+
+	// SecurityEntries lists the changelog entries for the release which describe security changes,
+	// as returned by ChangelogVersion.SecurityEntries.
+	SecurityEntries []string
+}
+
+// UpgradeReport describes every release between the current and target versions of an upgrade.
+type UpgradeReport struct {
+	// Product is the name of the product being upgraded.
+	Product string
+
+	// From is the version from which the product is being upgraded.
+	From string
+
+	// To is the version to which the product is being upgraded.
+	To string
+
+	// Steps describes each release after From, up to and including To, oldest version first.
+	Steps []UpgradeStep
+
+	// Withdrawn lists the releases among Steps which have been withdrawn, oldest version first.
+	Withdrawn []WithdrawnRelease
+}
+
+// SecurityEntries returns the security changelog entries of every step, oldest version first.
+func (r UpgradeReport) SecurityEntries() []string {
+	var entries []string
+	for _, step := range r.Steps {
+		entries = append(entries, step.SecurityEntries...)
+	}
+	return entries
+}
+
+// UpgradePath returns a report describing each release of the nominated product and license class
+// with a version greater than from, and less than or equal to to, together with the changelog
+// section for each. Prereleases are included only if to is itself a prerelease.
+//
+// Changelogs are retrieved once for each distinct ReleaseInfo.URLChangelog. Releases with no
+// changelog, or whose changelog does not describe them, have a nil UpgradeStep.Changelog; any other
+// failure to retrieve a changelog is returned as an error.
+func (c *Client) UpgradePath(ctx context.Context, product string, from string, to string, licenseClass *LicenseClass) (UpgradeReport, error) {
+	return upgradePath(ctx, c, c.fetchChangelog, product, from, to, licenseClass)
+}
+
+// UpgradePath returns a report describing each release of the nominated product and license class
+// retrieved from api between from and to, as described for Client.UpgradePath. Changelogs are
+// retrieved using httpClient, or http.DefaultClient if it is nil.
+func UpgradePath(ctx context.Context, api ReleasesAPI, httpClient *http.Client, product string, from string, to string, licenseClass *LicenseClass) (UpgradeReport, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	fetch := func(ctx context.Context, release ReleaseInfo) ([]ChangelogVersion, error) {
+		return fetchChangelog(ctx, httpClient, nil, release)
+	}
+	return upgradePath(ctx, api, fetch, product, from, to, licenseClass)
+}
+
+func upgradePath(ctx context.Context, api ReleasesAPI, fetch func(context.Context, ReleaseInfo) ([]ChangelogVersion, error), product string, from string, to string, licenseClass *LicenseClass) (UpgradeReport, error) {
+	fromVersion, err := parseVersion(from)
+	if err != nil {
+		return UpgradeReport{}, err
+	}
+	toVersion, err := parseVersion(to)
+	if err != nil {
+		return UpgradeReport{}, err
+	}
+	if fromVersion.compare(toVersion) >= 0 {
+		return UpgradeReport{}, fmt.Errorf("%w: %s is not newer than %s", ErrInvalidVersion, to, from)
+	}
+
+	items, err := api.Releases(ctx, product, licenseClass)
+	if err != nil {
+		return UpgradeReport{}, err
+	}
+
+	type candidate struct {
+		release ReleaseInfo
+		version version
+	}
+
+	var candidates []candidate
+	targetFound := false
+	for release, err := range items {
+		if err != nil {
+			return UpgradeReport{}, err
+		}
+
+		v, err := parseVersion(release.Version)
+		if err != nil || v.compare(fromVersion) <= 0 || v.compare(toVersion) > 0 {
+			continue
+		}
+		if v.compare(toVersion) == 0 {
+			targetFound = true
+		} else if v.prerelease != "" && toVersion.prerelease == "" {
+			continue
+		}
+
+		candidates = append(candidates, candidate{release: release, version: v})
+	}
+
+	if !targetFound {
+		return UpgradeReport{}, fmt.Errorf("%w: %s %s does not exist", ErrInvalidVersion, product, to)
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return a.version.compare(b.version)
+	})
+
+	report := UpgradeReport{
+		Product: product,
+		From:    from,
+		To:      to,
+	}
+
+	changelogs := make(map[string][]ChangelogVersion)
+	for _, candidate := range candidates {
+		step := UpgradeStep{Release: candidate.release}
+
+		if url := candidate.release.URLChangelog; url != "" {
+			versions, ok := changelogs[url]
+			if !ok {
+				versions, err = fetch(ctx, candidate.release)
+				if err != nil {
+					return UpgradeReport{}, err
+				}
+				changelogs[url] = versions
+			}
+
+			section, err := findChangelogVersion(versions, candidate.release)
+			switch {
+			case err == nil:
+				step.Changelog = &section
+				step.SecurityEntries = section.SecurityEntries()
+			case !errors.Is(err, ErrChangelogVersionNotFound):
+				return UpgradeReport{}, err
+			}
+		}
+
+		if candidate.release.Status.State == ReleaseStateWithdrawn {
+			report.Withdrawn = append(report.Withdrawn, WithdrawnRelease{
+				Version: candidate.release.Version,
+				Message: candidate.release.Status.Message,
+			})
+		}
+
+		report.Steps = append(report.Steps, step)
+	}
+
+	return report, nil
+}
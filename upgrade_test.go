@@ -0,0 +1,96 @@
+package releases_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+func TestClient_UpgradePath(t *testing.T) {
+	var changelogRequests atomic.Int32
+	var fixtures []releases.ReleaseInfo
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/releases/terraform", func(w http.ResponseWriter, r *http.Request) {
+		serveTestReleasesPage(t, w, r, fixtures)
+	})
+	mux.HandleFunc("GET /CHANGELOG.md", func(w http.ResponseWriter, r *http.Request) {
+		changelogRequests.Add(1)
+		_, _ = io.WriteString(w, testTerraformChangelog)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	release := func(version string, day int, status releases.ReleaseStatus) releases.ReleaseInfo {
+		return releases.ReleaseInfo{
+			Name:             "terraform",
+			Version:          version,
+			Status:           status,
+			TimestampCreated: time.Date(2023, time.September, day, 0, 0, 0, 0, time.UTC),
+			URLChangelog:     server.URL + "/CHANGELOG.md",
+		}
+	}
+	supported := releases.ReleaseStatus{State: releases.ReleaseStateSupported}
+	fixtures = []releases.ReleaseInfo{
+		release("1.4.7", 20, supported),
+		release("1.6.0-beta1", 15, supported),
+		release("1.5.7", 7, supported),
+		release("1.5.6", 5, releases.ReleaseStatus{State: releases.ReleaseStateWithdrawn, Message: "Regression in init"}),
+		release("1.5.5", 3, supported),
+	}
+
+	client, err := releases.New(releases.WithBaseURL(server.URL))
+	requireNoError(t, err)
+
+	t.Run("Range", func(t *testing.T) {
+		report, err := client.UpgradePath(context.Background(), "terraform", "1.4.7", "1.5.7", nil)
+		requireNoError(t, err)
+
+		var versions []string
+		for _, step := range report.Steps {
+			versions = append(versions, step.Release.Version)
+		}
+		requireEqual(t, []string{"1.5.5", "1.5.6", "1.5.7"}, versions)
+
+		requireEqual(t, true, report.Steps[0].Changelog == nil)
+		requireEqual(t, "August 23, 2023", report.Steps[1].Changelog.Date)
+		requireEqual(t, 2, len(report.Steps[2].Changelog.Entries(releases.ChangeCategoryBugFixes)))
+
+		requireEqual(t, 1, len(report.SecurityEntries()))
+		requireEqual(t, []releases.WithdrawnRelease{{Version: "1.5.6", Message: "Regression in init"}}, report.Withdrawn)
+		requireEqual(t, int32(1), changelogRequests.Load())
+	})
+
+	t.Run("Snapshot", func(t *testing.T) {
+		report, err := releases.UpgradePath(context.Background(), releases.NewSnapshot(fixtures...), server.Client(),
+			"terraform", "1.4.7", "1.5.7", nil)
+		requireNoError(t, err)
+
+		requireEqual(t, 3, len(report.Steps))
+		requireEqual(t, "August 23, 2023", report.Steps[1].Changelog.Date)
+	})
+
+	t.Run("Prerelease Target", func(t *testing.T) {
+		report, err := client.UpgradePath(context.Background(), "terraform", "1.5.7", "1.6.0-beta1", nil)
+		requireNoError(t, err)
+
+		requireEqual(t, 1, len(report.Steps))
+		requireEqual(t, "1.6.0-beta1", report.Steps[0].Release.Version)
+	})
+
+	t.Run("Invalid Range", func(t *testing.T) {
+		for _, versions := range [][2]string{{"1.5.7", "1.5.5"}, {"1.5.5", "1.5.8"}} {
+			_, err := client.UpgradePath(context.Background(), "terraform", versions[0], versions[1], nil)
+			if !errors.Is(err, releases.ErrInvalidVersion) {
+				t.Fatalf("expected ErrInvalidVersion for %v, got: %v", versions, err)
+			}
+		}
+	})
+}
@@ -3,6 +3,7 @@ package releases_test
 import (
 	"fmt"
 	"iter"
+	"net/http"
 	"reflect"
 	"testing"
 )
@@ -34,3 +35,9 @@ func collectResults[T any](t *testing.T, seq iter.Seq2[T, error]) []T {
 
 	return result
 }
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,199 @@
+package releases
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// releasesAPIMediaType is the content type with which the Releases API serves JSON documents.
+const releasesAPIMediaType = "application/vnd+hashicorp.releases-api.v1+json"
+
+func checkContentType(resp *http.Response) error {
+	if contentType := resp.Header.Get("Content-Type"); contentType != releasesAPIMediaType {
+		if contentType == "" {
+			contentType = "<none>"
+		}
+		return fmt.Errorf("%w: %s", ErrInvalidResponseContentType, contentType)
+	}
+	return nil
+}
+
+// Violation describes a single way in which release metadata fails validation.
+type Violation struct {
+	// Product is the name of the product of which the invalid release is a version.
+	Product string
+
+	// Version is the version number of the invalid release.
+	Version string
+
+	// Field is the path to the invalid member of the JSON representation of the release, for
+	// example "builds[2].url".
+	Field string
+
+	// Problem describes the way in which the member is invalid.
+	Problem string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %s: %s: %s", v.Product, v.Version, v.Field, v.Problem)
+}
+
+// ValidationError is returned when release metadata fails validation, either by Validate or by a
+// Client constructed using WithStrictDecoding. It wraps ErrInvalidResponseBody.
+type ValidationError struct {
+	// Violations lists each way in which the metadata is invalid.
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	descriptions := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		descriptions = append(descriptions, violation.String())
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidResponseBody, strings.Join(descriptions, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidResponseBody
+}
+
+// Validate checks that the release metadata satisfies the invariants documented by the Releases
+// API, and returns a *ValidationError describing each violation if it does not. In particular:
+//
+//   - No JSON members were present which are not recognized by this library,
+//   - A withdrawn release has a status message explaining the withdrawal,
+//   - Each build has a non-empty OS, architecture and URL,
+//   - Each non-empty URL is an absolute https URL,
+//   - The license class is one of "oss", "enterprise" or "hcp", and
+//   - The creation and update timestamps are set.
+func (r ReleaseInfo) Validate() error {
+	var violations []Violation
+	report := func(field string, format string, args ...any) {
+		violations = append(violations, Violation{
+			Product: r.Name,
+			Version: r.Version,
+			Field:   field,
+			Problem: fmt.Sprintf(format, args...),
+		})
+	}
+	for _, name := range slices.Sorted(maps.Keys(r.Extra())) {
+		report(name, "unknown field")
+	}
+	for _, name := range slices.Sorted(maps.Keys(r.Status.Extra())) {
+		report("status."+name, "unknown field")
+	}
+
+	if r.Name == "" {
+		report("name", "must not be empty")
+	}
+	if r.Version == "" {
+		report("version", "must not be empty")
+	}
+
+	switch r.Status.State {
+	case ReleaseStateSupported, ReleaseStateUnsupported:
+	case ReleaseStateWithdrawn:
+		if r.Status.Message == "" {
+			report("status.message", "must not be empty for a withdrawn release")
+		}
+	default:
+		report("status.state", "unknown state %q", r.Status.State)
+	}
+
+	switch r.LicenseClass {
+	case *LicenseClassOSS, *LicenseClassEnterprise, *LicenseClassHCP:
+	default:
+		report("license_class", "unknown license class %q", r.LicenseClass)
+	}
+
+	if r.TimestampCreated.IsZero() {
+		report("timestamp_created", "must be set")
+	}
+	if r.TimestampUpdated.IsZero() {
+		report("timestamp_updated", "must be set")
+	}
+
+	for i, build := range r.Builds {
+		prefix := fmt.Sprintf("builds[%d]", i)
+		for _, name := range slices.Sorted(maps.Keys(build.Extra())) {
+			report(prefix+"."+name, "unknown field")
+		}
+		if build.OS == "" {
+			report(prefix+".os", "must not be empty")
+		}
+		if build.Arch == "" {
+			report(prefix+".arch", "must not be empty")
+		}
+		if build.URL == "" {
+			report(prefix+".url", "must not be empty")
+		} else if problem := checkHTTPSURL(build.URL); problem != "" {
+			report(prefix+".url", "%s", problem)
+		}
+	}
+
+	for field, value := range map[string]string{
+		"url_blogpost":                  r.URLBlogpost,
+		"url_changelog":                 r.URLChangelog,
+		"url_docker_registry_dockerhub": r.URLDockerRegistryDockerhub,
+		"url_docker_registry_ecr":       r.URLDockerRegistryECR,
+		"url_license":                   r.URLLicense,
+		"url_project_website":           r.URLProjectWebsite,
+		"url_release_notes":             r.URLReleaseNotes,
+		"url_shasums":                   r.URLSHASUMs,
+		"url_source_repository":         r.URLSourceRepository,
+	} {
+		if value == "" {
+			continue
+		}
+		if problem := checkHTTPSURL(value); problem != "" {
+			report(field, "%s", problem)
+		}
+	}
+	for i, value := range r.URLSHASUMsSignatures {
+		if problem := checkHTTPSURL(value); problem != "" {
+			report(fmt.Sprintf("url_shasums_signatures[%d]", i), "%s", problem)
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	slices.SortStableFunc(violations, func(a, b Violation) int {
+		return strings.Compare(a.Field, b.Field)
+	})
+	return &ValidationError{Violations: violations}
+}
+
+func checkHTTPSURL(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return fmt.Sprintf("invalid URL: %s", err)
+	}
+	if parsed.Scheme != "https" || parsed.Host == "" {
+		return fmt.Sprintf("must be an absolute https URL, got %q", value)
+	}
+	return ""
+}
+
+// validateReleases validates each of the supplied releases, and returns a single
+// *ValidationError describing every violation.
+func validateReleases(items []ReleaseInfo) error {
+	var violations []Violation
+	for _, item := range items {
+		var validationErr *ValidationError
+		if err := item.Validate(); err != nil && errors.As(err, &validationErr) {
+			violations = append(violations, validationErr.Violations...)
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: violations}
+}
@@ -0,0 +1,126 @@
+package releases_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+const testMediaType = "application/vnd+hashicorp.releases-api.v1+json"
+
+func TestReleaseInfo_Validate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		requireNoError(t, waypoint_0_11_4.Validate())
+		requireNoError(t, waypoint_0_1_0.Validate())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		release := releases.ReleaseInfo{
+			Name:         "vault",
+			Version:      "1.15.1",
+			LicenseClass: "community",
+			Status:       releases.ReleaseStatus{State: releases.ReleaseStateWithdrawn},
+			Builds: []releases.BuildInfo{
+				{OS: "linux", URL: "http://releases.hashicorp.com/vault/1.15.1/vault_1.15.1_linux_amd64.zip"},
+			},
+			TimestampCreated: time.Date(2023, time.October, 20, 10, 0, 0, 0, time.UTC),
+			URLSHASUMs:       "releases.hashicorp.com/vault/1.15.1/vault_1.15.1_SHA256SUMS",
+		}
+		release.SetExtra(map[string]json.RawMessage{"sbom_url": json.RawMessage(`""`)})
+
+		var validationErr *releases.ValidationError
+		if !errors.As(release.Validate(), &validationErr) {
+			t.Fatalf("expected a *ValidationError")
+		}
+		if !errors.Is(validationErr, releases.ErrInvalidResponseBody) {
+			t.Fatalf("expected error to wrap ErrInvalidResponseBody")
+		}
+
+		var fields []string
+		for _, violation := range validationErr.Violations {
+			requireEqual(t, "vault", violation.Product)
+			requireEqual(t, "1.15.1", violation.Version)
+			fields = append(fields, violation.Field)
+		}
+		requireEqual(t, []string{
+			"builds[0].arch",
+			"builds[0].url",
+			"license_class",
+			"sbom_url",
+			"status.message",
+			"timestamp_updated",
+			"url_shasums",
+		}, fields)
+	})
+}
+
+func TestWithStrictDecoding(t *testing.T) {
+	var contentType string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/releases/waypoint", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		serveTestReleasesPage(t, w, r, waypointReleases)
+	})
+	mux.HandleFunc("GET /v1/releases/waypoint/{version}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusOK)
+
+		release := waypoint_0_11_4
+		if r.PathValue("version") == "0.0.1" {
+			release.Status = releases.ReleaseStatus{State: releases.ReleaseStateWithdrawn}
+		}
+		requireNoError(t, json.NewEncoder(w).Encode(release))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL), releases.WithStrictDecoding())
+	requireNoError(t, err)
+
+	t.Run("Valid", func(t *testing.T) {
+		contentType = testMediaType
+
+		release, err := client.Release(context.Background(), "waypoint", "0.11.4")
+		requireNoError(t, err)
+		requireEqual(t, waypoint_0_11_4, release)
+
+		releasesIterator, err := client.Releases(context.Background(), "waypoint", releases.LicenseClassOSS)
+		requireNoError(t, err)
+		requireEqual(t, 43, len(collectResults(t, releasesIterator)))
+	})
+
+	t.Run("Invalid Content Type", func(t *testing.T) {
+		contentType = "application/json"
+
+		_, err := client.Release(context.Background(), "waypoint", "0.11.4")
+		if !errors.Is(err, releases.ErrInvalidResponseContentType) {
+			t.Fatalf("expected ErrInvalidResponseContentType, got: %v", err)
+		}
+
+		releasesIterator, err := client.Releases(context.Background(), "waypoint", releases.LicenseClassOSS)
+		requireNoError(t, err)
+		for _, err := range releasesIterator {
+			if !errors.Is(err, releases.ErrInvalidResponseContentType) {
+				t.Fatalf("expected ErrInvalidResponseContentType, got: %v", err)
+			}
+		}
+	})
+
+	t.Run("Invariant Violation", func(t *testing.T) {
+		contentType = testMediaType
+
+		_, err := client.Release(context.Background(), "waypoint", "0.0.1")
+
+		var validationErr *releases.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("expected a *ValidationError, got: %v", err)
+		}
+		requireEqual(t, "status.message", validationErr.Violations[0].Field)
+	})
+}
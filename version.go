@@ -0,0 +1,117 @@
+package releases
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// version is a parsed product version number. HashiCorp products use semantic versioning, though
+// older releases may omit the separator before a prerelease suffix (for example "0.5.0rc1"), and
+// enterprise releases carry build metadata (for example "1.15.0+ent").
+type version struct {
+	major, minor, patch int
+	prerelease          string
+	metadata            string
+}
+
+func parseVersion(s string) (version, error) {
+	var v version
+
+	rest := strings.TrimPrefix(s, "v")
+	if idx := strings.IndexByte(rest, '+'); idx >= 0 {
+		rest, v.metadata = rest[:idx], rest[idx+1:]
+	}
+
+	segments := []*int{&v.major, &v.minor, &v.patch}
+	for i, segment := range segments {
+		end := 0
+		for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			return version{}, fmt.Errorf("%w: %q is not a version number", ErrInvalidVersion, s)
+		}
+
+		n, err := strconv.Atoi(rest[:end])
+		if err != nil {
+			return version{}, fmt.Errorf("%w: %q: %w", ErrInvalidVersion, s, err)
+		}
+		*segment = n
+		rest = rest[end:]
+
+		if i < len(segments)-1 {
+			if !strings.HasPrefix(rest, ".") {
+				break
+			}
+			rest = rest[1:]
+		}
+	}
+
+	v.prerelease = strings.TrimLeft(rest, "-.")
+	return v, nil
+}
+
+func (v version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+	if v.prerelease != "" {
+		s += "-" + v.prerelease
+	}
+	if v.metadata != "" {
+		s += "+" + v.metadata
+	}
+	return s
+}
+
+// line returns the major.minor release line to which v belongs.
+func (v version) line() string {
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
+
+// compare returns -1, 0 or +1 depending on whether v precedes, is equal to, or follows other.
+// Precedence follows semantic versioning, so build metadata is ignored.
+func (v version) compare(other version) int {
+	if c := cmp.Compare(v.major, other.major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.minor, other.minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.patch, other.patch); c != 0 {
+		return c
+	}
+
+	switch {
+	case v.prerelease == other.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	}
+
+	left, right := strings.Split(v.prerelease, "."), strings.Split(other.prerelease, ".")
+	for i := range min(len(left), len(right)) {
+		if c := comparePrereleaseIdentifier(left[i], right[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(left), len(right))
+}
+
+func comparePrereleaseIdentifier(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		return cmp.Compare(an, bn)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
@@ -0,0 +1,85 @@
+package releases
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected version
+	}{
+		{input: "1.5.7", expected: version{major: 1, minor: 5, patch: 7}},
+		{input: "v0.11.4", expected: version{major: 0, minor: 11, patch: 4}},
+		{input: "1.6.0-beta1", expected: version{major: 1, minor: 6, prerelease: "beta1"}},
+		{input: "0.5.0rc1", expected: version{major: 0, minor: 5, prerelease: "rc1"}},
+		{input: "1.15.0+ent", expected: version{major: 1, minor: 15, metadata: "ent"}},
+		{input: "1.15.0-rc1+ent.hsm", expected: version{major: 1, minor: 15, prerelease: "rc1", metadata: "ent.hsm"}},
+		{input: "1.2", expected: version{major: 1, minor: 2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			actual, err := parseVersion(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tc.expected {
+				t.Fatalf("expected %#v, got %#v", tc.expected, actual)
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := parseVersion("latest"); !errors.Is(err, ErrInvalidVersion) {
+			t.Fatalf("expected ErrInvalidVersion, got %v", err)
+		}
+	})
+}
+
+func TestVersionCompare(t *testing.T) {
+	ordered := []string{
+		"0.9.9",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc1",
+		"1.0.0",
+		"1.0.1",
+		"1.10.0",
+	}
+
+	for i := range ordered {
+		for j := range ordered {
+			a, err := parseVersion(ordered[i])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			b, err := parseVersion(ordered[j])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			if actual := a.compare(b); actual != expected {
+				t.Fatalf("compare(%s, %s): expected %d, got %d", ordered[i], ordered[j], expected, actual)
+			}
+		}
+	}
+
+	t.Run("Metadata Ignored", func(t *testing.T) {
+		a, _ := parseVersion("1.15.0+ent")
+		b, _ := parseVersion("1.15.0")
+		if a.compare(b) != 0 {
+			t.Fatal("build metadata must not affect precedence")
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package releases
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"time"
+)
+
+// WatchEventType represents the kind of change observed by Watch.
+type WatchEventType string
+
+const (
+	// WatchEventNewRelease indicates that a release has been published since the previous poll.
+	WatchEventNewRelease WatchEventType = "new_release"
+
+	// WatchEventStatusChanged indicates that the support status of a known release has changed to
+	// a state other than withdrawn.
+	WatchEventStatusChanged WatchEventType = "status_changed"
+
+	// WatchEventWithdrawn indicates that a known release has been withdrawn.
+	WatchEventWithdrawn WatchEventType = "withdrawn"
+)
+
+// WatchEvent describes a change to the releases of a product observed by Watch.
+type WatchEvent struct {
+	// Type is the kind of change which was observed.
+	Type WatchEventType
+
+	// Release is the metadata for the release as of the poll at which the change was observed.
+	Release ReleaseInfo
+
+	// PreviousStatus is the status of the release as of the preceding poll. It is the zero value
+	// for events of type WatchEventNewRelease.
+	PreviousStatus ReleaseStatus
+}
+
+const (
+	watchJitterFraction = 0.1
+
+	// watchMaxBackoffShift limits the exponential backoff applied after consecutive failures to
+	// 2^4 = 16 times the polling interval.
+	watchMaxBackoffShift = 4
+)
+
+// Watch returns an iter.Seq2 which polls the first page of releases of the nominated product and
+// license class every interval, and yields an event for each release which has been published,
+// has changed support status, or has been withdrawn since the previous poll. The first successful
+// poll establishes which releases are already known, and does not produce events. Until it
+// succeeds, errors from failed polls wrap ErrNoBaseline, and the poll is retried. Every release
+// observed is remembered, and a release not previously observed is reported as new only if it was
+// created no earlier than the newest release already known. An older release which reappears on
+// the page, for example because newer releases were removed, therefore produces no event.
+//
+// Each interval is subject to random jitter of up to 10% either way. If a poll fails, the error is
+// yielded and the interval is doubled for each consecutive failure, up to 16 times the nominated
+// interval. Iteration may continue after an error is yielded. The sequence ends when the loop
+// over it exits, or ctx is cancelled.
+func (c *Client) Watch(ctx context.Context, product string, licenseClass *LicenseClass, interval time.Duration) (iter.Seq2[WatchEvent, error], error) {
+	return Watch(ctx, c, product, licenseClass, interval)
+}
+
+// Watch returns an iter.Seq2 which polls the first page of releases of the nominated product and
+// license class retrieved from api every interval, as described for Client.Watch. If api caches
+// results, such as a CachingAPI, polls may observe stale pages.
+func Watch(ctx context.Context, api ReleasesAPI, product string, licenseClass *LicenseClass, interval time.Duration) (iter.Seq2[WatchEvent, error], error) {
+	if product == "" {
+		return nil, fmt.Errorf("%w: may not be empty", ErrInvalidProduct)
+	}
+	if err := validateLicenseClass(licenseClass); err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("%w: must be positive, got %s", ErrInvalidInterval, interval)
+	}
+
+	return func(yield func(WatchEvent, error) bool) {
+		var known map[string]ReleaseStatus
+		var latest time.Time
+		failures := 0
+
+		for {
+			page, err := firstPage(ctx, api, product, licenseClass)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				if known == nil {
+					err = fmt.Errorf("%w: %w", ErrNoBaseline, err)
+				}
+				if !yield(WatchEvent{}, err) {
+					return
+				}
+				failures++
+			default:
+				failures = 0
+				if known == nil {
+					known = map[string]ReleaseStatus{}
+					_, latest = diffReleases(known, latest, page)
+					break
+				}
+
+				var events []WatchEvent
+				events, latest = diffReleases(known, latest, page)
+				for _, event := range events {
+					if !yield(event, nil) {
+						return
+					}
+				}
+			}
+
+			delay := jitter(interval << min(failures, watchMaxBackoffShift))
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}, nil
+}
+
+// firstPage returns the first page of releases of product retrieved from api.
+func firstPage(ctx context.Context, api ReleasesAPI, product string, licenseClass *LicenseClass) ([]ReleaseInfo, error) {
+	pages, err := api.ReleasesPaged(ctx, product, licenseClass)
+	if err != nil {
+		return nil, err
+	}
+
+	for page, err := range pages {
+		return page, err
+	}
+	return nil, nil
+}
+
+// diffReleases returns events describing the differences between the known release statuses,
+// keyed by version, and those on the supplied page, oldest release first, and records the latter in
+// known. Releases which are not known are new only if created no earlier than latest, the creation
+// time of the newest known release. The creation time of the newest release is returned.
+func diffReleases(known map[string]ReleaseStatus, latest time.Time, page []ReleaseInfo) ([]WatchEvent, time.Time) {
+	var events []WatchEvent
+	newest := latest
+
+	for i := len(page) - 1; i >= 0; i-- {
+		release := page[i]
+
+		previous, ok := known[release.Version]
+		known[release.Version] = release.Status
+		if release.TimestampCreated.After(newest) {
+			newest = release.TimestampCreated
+		}
+
+		switch {
+		case !ok && release.TimestampCreated.Before(latest):
+			continue
+		case !ok:
+			events = append(events, WatchEvent{Type: WatchEventNewRelease, Release: release})
+		case previous.State == release.Status.State:
+			continue
+		case release.Status.State == ReleaseStateWithdrawn:
+			events = append(events, WatchEvent{Type: WatchEventWithdrawn, Release: release, PreviousStatus: previous})
+		default:
+			events = append(events, WatchEvent{Type: WatchEventStatusChanged, Release: release, PreviousStatus: previous})
+		}
+	}
+
+	return events, newest
+}
+
+func jitter(d time.Duration) time.Duration {
+	spread := float64(d) * watchJitterFraction
+	return d + time.Duration((rand.Float64()*2-1)*spread)
+}
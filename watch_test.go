@@ -0,0 +1,256 @@
+package releases_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+func TestClient_Watch(t *testing.T) {
+	supported := releases.ReleaseStatus{State: releases.ReleaseStateSupported}
+	unsupported := releases.ReleaseStatus{State: releases.ReleaseStateUnsupported}
+	withdrawn := releases.ReleaseStatus{State: releases.ReleaseStateWithdrawn, Message: "Critical regression"}
+
+	release := func(version string, status releases.ReleaseStatus) releases.ReleaseInfo {
+		return releases.ReleaseInfo{Name: "vault", Version: version, Status: status}
+	}
+
+	// Each element is the first page served for successive polls. A nil element produces a server
+	// error, and the final element is repeated once the script is exhausted.
+	polls := [][]releases.ReleaseInfo{
+		{release("1.0.0", supported)},
+		{release("1.0.0", supported)},
+		{release("1.1.0", supported), release("1.0.1", supported), release("1.0.0", supported)},
+		nil,
+		{release("1.1.0", withdrawn), release("1.0.1", supported), release("1.0.0", unsupported)},
+	}
+
+	var pollCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("after") {
+			t.Errorf("unexpected after parameter: %s", r.URL.Query().Get("after"))
+		}
+
+		poll := polls[min(int(pollCount.Add(1))-1, len(polls)-1)]
+		if poll == nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		requireNoError(t, json.NewEncoder(w).Encode(poll))
+	}))
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL))
+	requireNoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := client.Watch(ctx, "vault", releases.LicenseClassOSS, 5*time.Millisecond)
+	requireNoError(t, err)
+
+	type observation struct {
+		eventType releases.WatchEventType
+		version   string
+		previous  releases.ReleaseState
+	}
+
+	var observed []observation
+	var errorCount int
+	for event, err := range events {
+		if err != nil {
+			if !errors.Is(err, releases.ErrInvalidStatusCode) || errors.Is(err, releases.ErrNoBaseline) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			errorCount++
+			continue
+		}
+
+		observed = append(observed, observation{event.Type, event.Release.Version, event.PreviousStatus.State})
+		if len(observed) == 4 {
+			break
+		}
+	}
+
+	requireEqual(t, 1, errorCount)
+	requireEqual(t, []observation{
+		{releases.WatchEventNewRelease, "1.0.1", ""},
+		{releases.WatchEventNewRelease, "1.1.0", ""},
+		{releases.WatchEventStatusChanged, "1.0.0", releases.ReleaseStateSupported},
+		{releases.WatchEventWithdrawn, "1.1.0", releases.ReleaseStateSupported},
+	}, observed)
+}
+
+func TestClient_Watch_Baseline(t *testing.T) {
+	release := func(version string) releases.ReleaseInfo {
+		return releases.ReleaseInfo{Name: "vault", Version: version}
+	}
+
+	// The first two polls fail, so the third establishes the baseline. 1.0.0 then leaves the page
+	// and reappears, which is not reported.
+	polls := [][]releases.ReleaseInfo{
+		nil,
+		nil,
+		{release("1.0.0")},
+		{release("1.1.0"), release("1.0.0")},
+		{release("1.1.0")},
+		{release("1.1.0"), release("1.0.0")},
+		{release("1.2.0"), release("1.1.0"), release("1.0.0")},
+	}
+
+	var pollCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		poll := polls[min(int(pollCount.Add(1))-1, len(polls)-1)]
+		if poll == nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		requireNoError(t, json.NewEncoder(w).Encode(poll))
+	}))
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL))
+	requireNoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := client.Watch(ctx, "vault", nil, time.Millisecond)
+	requireNoError(t, err)
+
+	var observed []string
+	var errorCount int
+	for event, err := range events {
+		if err != nil {
+			if !errors.Is(err, releases.ErrNoBaseline) {
+				t.Fatalf("expected ErrNoBaseline, got: %v", err)
+			}
+			errorCount++
+			continue
+		}
+
+		observed = append(observed, event.Release.Version)
+		if len(observed) == 2 {
+			break
+		}
+	}
+
+	requireEqual(t, 2, errorCount)
+	requireEqual(t, []string{"1.1.0", "1.2.0"}, observed)
+}
+
+func TestClient_Watch_OlderRelease(t *testing.T) {
+	created := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	release := func(version string, age time.Duration) releases.ReleaseInfo {
+		return releases.ReleaseInfo{Name: "vault", Version: version, TimestampCreated: created.Add(-age)}
+	}
+
+	// 0.9.0 was never observed, but returns to the page when 1.1.0 is removed. Since it is older
+	// than the releases already known, it is not reported as new.
+	polls := [][]releases.ReleaseInfo{
+		{release("1.1.0", 0), release("1.0.0", time.Hour)},
+		{release("1.0.0", time.Hour), release("0.9.0", 2*time.Hour)},
+		{release("1.2.0", -time.Hour), release("1.0.0", time.Hour), release("0.9.0", 2*time.Hour)},
+	}
+
+	var pollCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		requireNoError(t, json.NewEncoder(w).Encode(polls[min(int(pollCount.Add(1))-1, len(polls)-1)]))
+	}))
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL))
+	requireNoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := client.Watch(ctx, "vault", nil, time.Millisecond)
+	requireNoError(t, err)
+
+	var observed []string
+	for event, err := range events {
+		requireNoError(t, err)
+		observed = append(observed, event.Release.Version)
+		break
+	}
+	requireEqual(t, []string{"1.2.0"}, observed)
+}
+
+func TestWatch(t *testing.T) {
+	polls := [][]releases.ReleaseInfo{
+		{{Name: "vault", Version: "1.0.0"}},
+		{{Name: "vault", Version: "1.1.0"}, {Name: "vault", Version: "1.0.0"}},
+	}
+
+	var pollCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		requireNoError(t, json.NewEncoder(w).Encode(polls[min(int(pollCount.Add(1))-1, len(polls)-1)]))
+	}))
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL))
+	requireNoError(t, err)
+	api := releases.NewLoggingAPI(client, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := releases.Watch(ctx, api, "vault", nil, time.Millisecond)
+	requireNoError(t, err)
+
+	var observed []string
+	for event, err := range events {
+		requireNoError(t, err)
+		requireEqual(t, releases.WatchEventNewRelease, event.Type)
+		observed = append(observed, event.Release.Version)
+		break
+	}
+	requireEqual(t, []string{"1.1.0"}, observed)
+}
+
+func TestClient_Watch_Cancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		requireNoError(t, json.NewEncoder(w).Encode([]releases.ReleaseInfo{}))
+	}))
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL))
+	requireNoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	events, err := client.Watch(ctx, "vault", nil, time.Hour)
+	requireNoError(t, err)
+
+	for event, err := range events {
+		t.Fatalf("unexpected event %v or error %v", event, err)
+	}
+}
+
+func TestClient_Watch_InvalidInterval(t *testing.T) {
+	client, err := releases.New()
+	requireNoError(t, err)
+
+	_, err = client.Watch(context.Background(), "vault", nil, 0)
+	if !errors.Is(err, releases.ErrInvalidInterval) {
+		t.Fatalf("expected ErrInvalidInterval, got: %v", err)
+	}
+}
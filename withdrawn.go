@@ -0,0 +1,111 @@
+package releases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// CheckWithdrawn returns a *ReleaseWithdrawnError if the release has been withdrawn, and nil
+// otherwise.
+func (r ReleaseInfo) CheckWithdrawn() error {
+	if r.Status.State != ReleaseStateWithdrawn {
+		return nil
+	}
+
+	return &ReleaseWithdrawnError{
+		Product: r.Name,
+		Version: r.Version,
+		Message: r.Status.Message,
+	}
+}
+
+// checkWithdrawn applies the withdrawn release policy of the client to a release which has been
+// selected for use: it returns a *ReleaseWithdrawnError if the release has been withdrawn, unless
+// the client was constructed using WithAllowWithdrawn.
+func (c *Client) checkWithdrawn(release ReleaseInfo) error {
+	if c.opts.allowWithdrawn {
+		return nil
+	}
+	return release.CheckWithdrawn()
+}
+
+// PinnedRelease identifies a specific version of a product, as might be recorded in a lockfile.
+type PinnedRelease struct {
+	// Product is the name of the product.
+	Product string
+
+	// Version is the version number of the release.
+	Version string
+}
+
+// CheckPinned retrieves the current status of each of the nominated releases, and returns a
+// *ReleaseWithdrawnError for each which has since been withdrawn, in the order in which they were
+// supplied. Duplicate releases are checked only once.
+//
+// Requests are made concurrently, subject to the limit configured using WithConcurrency. If the
+// status of any release cannot be retrieved, the withdrawn releases among the remainder are
+// returned, along with an error joining each failure.
+func (c *Client) CheckPinned(ctx context.Context, pins []PinnedRelease) ([]*ReleaseWithdrawnError, error) {
+	return checkPinned(ctx, c, c.opts.concurrency, pins)
+}
+
+// CheckPinned retrieves the current status of each of the nominated releases from api, as
+// described for Client.CheckPinned. At most concurrency requests are made concurrently, and values
+// less than 1 are rejected with an error wrapping ErrInvalidConcurrency.
+func CheckPinned(ctx context.Context, api ReleasesAPI, concurrency int, pins []PinnedRelease) ([]*ReleaseWithdrawnError, error) {
+	if err := validateConcurrency(concurrency); err != nil {
+		return nil, err
+	}
+	return checkPinned(ctx, api, concurrency, pins)
+}
+
+func checkPinned(ctx context.Context, api ReleasesAPI, concurrency int, pins []PinnedRelease) ([]*ReleaseWithdrawnError, error) {
+	unique := make([]PinnedRelease, 0, len(pins))
+	for _, pin := range pins {
+		if pin.Product == "" {
+			return nil, fmt.Errorf("%w: may not be empty", ErrInvalidProduct)
+		}
+		if pin.Version == "" {
+			return nil, fmt.Errorf("%w: may not be empty", ErrInvalidVersion)
+		}
+		if !slices.Contains(unique, pin) {
+			unique = append(unique, pin)
+		}
+	}
+
+	var mu sync.Mutex
+	withdrawn := make(map[PinnedRelease]*ReleaseWithdrawnError)
+	failures := make(map[PinnedRelease]error)
+
+	forEachConcurrently(ctx, concurrency, unique, func(ctx context.Context, pin PinnedRelease) {
+		release, err := api.Release(ctx, pin.Product, pin.Version)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			failures[pin] = fmt.Errorf("%s %s: %w", pin.Product, pin.Version, err)
+			return
+		}
+
+		var withdrawnErr *ReleaseWithdrawnError
+		if errors.As(release.CheckWithdrawn(), &withdrawnErr) {
+			withdrawn[pin] = withdrawnErr
+		}
+	})
+
+	var result []*ReleaseWithdrawnError
+	var errs []error
+	for _, pin := range unique {
+		if err, ok := withdrawn[pin]; ok {
+			result = append(result, err)
+		}
+		if err, ok := failures[pin]; ok {
+			errs = append(errs, err)
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
@@ -0,0 +1,178 @@
+package releases_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	releases "github.com/jen20/go-hashicorp-releases-client"
+)
+
+var terraform_1_5_6_withdrawn = releases.ReleaseInfo{
+	Name:    "terraform",
+	Version: "1.5.6",
+	Status: releases.ReleaseStatus{
+		State:   releases.ReleaseStateWithdrawn,
+		Message: "Critical regression in provider installation",
+	},
+}
+
+func makeTestWithdrawnHandler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/releases/terraform/{version}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("version") {
+		case "latest", "1.5.6":
+			w.WriteHeader(http.StatusOK)
+			requireNoError(t, json.NewEncoder(w).Encode(terraform_1_5_6_withdrawn))
+		case "1.5.5":
+			w.WriteHeader(http.StatusOK)
+			requireNoError(t, json.NewEncoder(w).Encode(releases.ReleaseInfo{
+				Name:    "terraform",
+				Version: "1.5.5",
+				Status:  releases.ReleaseStatus{State: releases.ReleaseStateSupported},
+			}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	return mux
+}
+
+func TestClient_LatestRelease_Withdrawn(t *testing.T) {
+	server := httptest.NewServer(makeTestWithdrawnHandler(t))
+	defer server.Close()
+
+	t.Run("Refused By Default", func(t *testing.T) {
+		client, err := releases.New(releases.WithBaseURL(server.URL))
+		requireNoError(t, err)
+
+		release, err := client.LatestRelease(context.Background(), "terraform", nil)
+
+		var withdrawnErr *releases.ReleaseWithdrawnError
+		if !errors.As(err, &withdrawnErr) {
+			t.Fatalf("expected a *ReleaseWithdrawnError, got: %v", err)
+		}
+		if !errors.Is(err, releases.ErrReleaseWithdrawn) {
+			t.Fatalf("expected error to wrap ErrReleaseWithdrawn, got: %v", err)
+		}
+		requireEqual(t, "Critical regression in provider installation", withdrawnErr.Message)
+		requireEqual(t, "1.5.6", withdrawnErr.Version)
+		requireEqual(t, releases.ReleaseInfo{}, release)
+	})
+
+	t.Run("Allowed", func(t *testing.T) {
+		client, err := releases.New(releases.WithBaseURL(server.URL), releases.WithAllowWithdrawn())
+		requireNoError(t, err)
+
+		release, err := client.LatestRelease(context.Background(), "terraform", nil)
+		requireNoError(t, err)
+		requireEqual(t, terraform_1_5_6_withdrawn, release)
+	})
+}
+
+func TestClient_WithdrawnPolicy(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /terraform_1.5.6_SHA256SUMS", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  terraform_1.5.6_linux_amd64.zip\n"))
+	})
+	mux.HandleFunc("GET /CHANGELOG.md", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testTerraformChangelog))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	release := terraform_1_5_6_withdrawn
+	release.TimestampCreated = time.Date(2023, time.August, 23, 0, 0, 0, 0, time.UTC)
+	release.URLChangelog = server.URL + "/CHANGELOG.md"
+	release.URLSHASUMs = server.URL + "/terraform_1.5.6_SHA256SUMS"
+	release.URLSHASUMsSignatures = []string{server.URL + "/terraform_1.5.6_SHA256SUMS.72D7468F.sig"}
+
+	calls := map[string]func(*releases.Client) error{
+		"Checksums": func(client *releases.Client) error {
+			_, err := client.Checksums(context.Background(), release)
+			return err
+		},
+		"Changelog": func(client *releases.Client) error {
+			_, err := client.Changelog(context.Background(), release)
+			return err
+		},
+		"SelectSignature": func(client *releases.Client) error {
+			_, err := client.SelectSignature(release)
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			client, err := releases.New()
+			requireNoError(t, err)
+
+			var withdrawnErr *releases.ReleaseWithdrawnError
+			if err := call(client); !errors.As(err, &withdrawnErr) {
+				t.Fatalf("expected a *ReleaseWithdrawnError, got: %v", err)
+			}
+
+			client, err = releases.New(releases.WithAllowWithdrawn())
+			requireNoError(t, err)
+			requireNoError(t, call(client))
+		})
+	}
+}
+
+func TestClient_CheckPinned(t *testing.T) {
+	server := httptest.NewServer(makeTestWithdrawnHandler(t))
+	defer server.Close()
+
+	client, err := releases.New(releases.WithBaseURL(server.URL))
+	requireNoError(t, err)
+
+	withdrawn, err := client.CheckPinned(context.Background(), []releases.PinnedRelease{
+		{Product: "terraform", Version: "1.5.5"},
+		{Product: "terraform", Version: "1.5.6"},
+		{Product: "terraform", Version: "9.9.9"},
+		{Product: "terraform", Version: "1.5.6"},
+	})
+	if !errors.Is(err, releases.ErrNotFound) {
+		t.Fatalf("expected error to wrap ErrNotFound, got: %v", err)
+	}
+
+	requireEqual(t, []*releases.ReleaseWithdrawnError{{
+		Product: "terraform",
+		Version: "1.5.6",
+		Message: "Critical regression in provider installation",
+	}}, withdrawn)
+}
+
+func TestCheckPinned(t *testing.T) {
+	snapshot := releases.NewSnapshot(
+		releases.ReleaseInfo{Name: "terraform", Version: "1.5.5"},
+		releases.ReleaseInfo{Name: "terraform", Version: "1.5.6", Status: releases.ReleaseStatus{
+			State:   releases.ReleaseStateWithdrawn,
+			Message: "Critical regression in provider installation",
+		}},
+	)
+
+	_, err := releases.CheckPinned(context.Background(), snapshot, 0, nil)
+	if !errors.Is(err, releases.ErrInvalidConcurrency) {
+		t.Fatalf("expected ErrInvalidConcurrency, got: %v", err)
+	}
+
+	withdrawn, err := releases.CheckPinned(context.Background(), snapshot, 2, []releases.PinnedRelease{
+		{Product: "terraform", Version: "1.5.5"},
+		{Product: "terraform", Version: "1.5.6"},
+		{Product: "terraform", Version: "9.9.9"},
+	})
+	if !errors.Is(err, releases.ErrNotFound) {
+		t.Fatalf("expected error to wrap ErrNotFound, got: %v", err)
+	}
+
+	requireEqual(t, []*releases.ReleaseWithdrawnError{{
+		Product: "terraform",
+		Version: "1.5.6",
+		Message: "Critical regression in provider installation",
+	}}, withdrawn)
+}